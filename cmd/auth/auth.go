@@ -0,0 +1,11 @@
+package auth
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Inspect credentials",
+	Long:  "Inspect the credentials used to authenticate against package sources.",
+}
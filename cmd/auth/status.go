@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var host string
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which GitHub token is used and what it grants",
+	Long: "Show which source the GitHub token is taken from (GH_TOKEN, GITHUB_TOKEN, per-host environment variables, " +
+		"~/.netrc, the gh CLI or a git credential helper), its scopes and its expiry. The token itself is never printed.",
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		status, statusErr := github.GetAuthStatus(host)
+
+		output := &formatter.AuthStatusOutput{
+			Host:       status.Host,
+			Source:     status.Source,
+			TokenType:  status.TokenType,
+			Login:      status.Login,
+			Scopes:     status.Scopes,
+			Expiration: status.Expiration,
+		}
+		for _, attempt := range status.Attempts {
+			item := formatter.AuthSourceItem{Source: attempt.Source, Status: "not configured"}
+			switch {
+			case attempt.Found:
+				item.Status = "found"
+			case attempt.Err != nil:
+				item.Status = "error: " + attempt.Err.Error()
+			}
+			output.Sources = append(output.Sources, item)
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		if err := f.Format(output); err != nil {
+			return err
+		}
+		return statusErr
+	},
+}
+
+func init() {
+	statusCmd.Flags().StringVar(&host, "host", github.DefaultHost, "GitHub host to check")
+	Cmd.AddCommand(statusCmd)
+}
@@ -0,0 +1,11 @@
+package bulk
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Query many packages at once",
+	Long:  "Query many packages across ecosystems at once, given as Package URLs (e.g. pkg:npm/express, pkg:pypi/requests).",
+}
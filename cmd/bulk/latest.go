@@ -0,0 +1,96 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	purlsFile     string
+	concurrency   int
+	minReleaseAge time.Duration
+	strategy      = registry.StrategyHighest
+)
+
+var latestCmd = &cobra.Command{
+	Use:   "latest [purl]...",
+	Short: "Show the latest version of many packages",
+	Long: "Show the latest version of many packages, given as Package URLs as arguments or with --file, one per line. " +
+		"The latest version is picked by --strategy, as for <ecosystem> latest; pkg:docker images resolve to their " +
+		"latest tag and pkg:github repositories to their latest release, as for purl latest. With --output json, results are " +
+		"streamed as newline-delimited JSON as they arrive; otherwise they are printed as a table at the end. " +
+		"A package that fails is reported with its error and does not stop the others.",
+	RunE: func(_ *cobra.Command, args []string) error {
+		purls := args
+		if purlsFile != "" {
+			lines, err := shared.ReadLines(purlsFile)
+			if err != nil {
+				return err
+			}
+			purls = append(purls, lines...)
+		}
+		if len(purls) == 0 {
+			return fmt.Errorf("requires at least one package URL argument or --file")
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONLinesFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		failed := 0
+		table := &formatter.LatestBatchOutput{}
+		options := registry.Options{MinReleaseAge: minReleaseAge, Strategy: strategy}
+		err := registry.BulkLatest(context.Background(), purls, concurrency, options, func(results []registry.LatestResult) error {
+			output := &formatter.LatestBatchOutput{Results: make([]formatter.LatestBatchItem, len(results))}
+			for i, r := range results {
+				output.Results[i] = formatter.LatestBatchItem{Package: r.PURL, Version: r.Version}
+				if r.Err != nil {
+					output.Results[i].Error = r.Err.Error()
+					failed++
+				}
+			}
+			// The table is aligned across all results, so it is only
+			// printed once they are in.
+			if shared.OutputFormat != shared.JSON {
+				table.Results = append(table.Results, output.Results...)
+				return nil
+			}
+			return f.Format(output)
+		})
+		if err != nil {
+			return err
+		}
+
+		if shared.OutputFormat != shared.JSON {
+			if err := f.Format(table); err != nil {
+				return err
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d packages failed", failed, len(purls))
+		}
+		return nil
+	},
+}
+
+func init() {
+	latestCmd.Flags().StringVarP(&purlsFile, "file", "f", "", "Read Package URLs from a file, one per line ('-' for stdin)")
+	latestCmd.Flags().IntVar(&concurrency, "concurrency", registry.DefaultBulkConcurrency, "Number of packages to query at once")
+	latestCmd.Flags().VarP(
+		shared.NewTimespanValue(&minReleaseAge),
+		"min-release-age",
+		"",
+		"ignore versions released within this timespan when selecting the latest (e.g. 7d, 6mo, 1y); ecosystems without release timestamps are unaffected",
+	)
+	latestCmd.Flags().Var(&strategy, "strategy", "how to pick the latest version: highest (in the ecosystem's version ordering), newest (most recently published) or registry (as reported by the registry)")
+	Cmd.AddCommand(latestCmd)
+}
@@ -0,0 +1,108 @@
+package cooldown
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/manifest"
+	"github.com/acidghost/a555pq/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	minReleaseAge time.Duration
+	allowlistFile string
+	concurrency   int
+	format        string
+	allowUndated  bool
+)
+
+var Cmd = &cobra.Command{
+	Use:   "check-cooldown <lockfile>...",
+	Short: "Fail when lockfiles lock recently released package versions",
+	Long: "Look up when every package version locked by the given lockfiles was published, and fail when any is " +
+		"younger than --min-release-age. Package versions that cannot be looked up fail the check too, unless " +
+		"allowlisted. The allowlist file lists Package URLs, one per line, e.g. pkg:npm/left-pad@1.3.0; a URL " +
+		"without a version allows every version of the package. Versions whose registry does not tell when they " +
+		"were published, such as Terraform providers, fail unless --allow-undated is given.",
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if minReleaseAge <= 0 {
+			return fmt.Errorf("--min-release-age is required")
+		}
+
+		var f formatter.OutputFormatter
+		switch {
+		case format == "sarif":
+			f = formatter.NewSARIFFormatter()
+		case format != "report":
+			return fmt.Errorf("invalid format '%s', expected report or sarif", format)
+		case shared.OutputFormat == shared.JSON:
+			f = formatter.NewJSONFormatter()
+		default:
+			f = formatter.NewTableFormatter()
+		}
+
+		options := registry.CooldownOptions{MinReleaseAge: minReleaseAge, Concurrency: concurrency, AllowUndated: allowUndated}
+		if allowlistFile != "" {
+			lines, err := shared.ReadLines(allowlistFile)
+			if err != nil {
+				return err
+			}
+			if options.Allowlist, err = registry.ParseAllowlist(lines); err != nil {
+				return err
+			}
+		}
+
+		files := make([]registry.LockedFile, 0, len(args))
+		for _, path := range args {
+			deps, err := manifest.ParseFile(path)
+			if err != nil {
+				return err
+			}
+			files = append(files, registry.LockedFile{Path: path, Dependencies: deps})
+		}
+
+		// From here on, failing is the verdict of the check rather than a
+		// usage error.
+		cmd.SilenceUsage = true
+		output := registry.CheckCooldown(context.Background(), files, options)
+		if err := f.Format(output); err != nil {
+			return err
+		}
+
+		var young, undated, failed int
+		for _, finding := range output.Findings {
+			switch {
+			case finding.Allowed:
+			case finding.Error != "":
+				failed++
+			case finding.Undated:
+				undated++
+			default:
+				young++
+			}
+		}
+		if young > 0 || undated > 0 || failed > 0 {
+			return fmt.Errorf("cooldown check failed: %d package versions younger than %s, %d without a publish time, %d lookups failed",
+				young, output.MinReleaseAge, undated, failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	Cmd.Flags().VarP(
+		shared.NewTimespanValue(&minReleaseAge),
+		"min-release-age",
+		"",
+		"minimum time since a locked version was published (e.g. 7d, 6mo, 1y); required",
+	)
+	Cmd.Flags().StringVar(&allowlistFile, "allowlist", "", "File of Package URLs exempt from the check, one per line ('-' for stdin)")
+	Cmd.Flags().IntVar(&concurrency, "concurrency", registry.DefaultCooldownConcurrency, "Number of registry requests to run at once")
+	Cmd.Flags().BoolVar(&allowUndated, "allow-undated", false, "pass package versions whose registry does not tell when they were published")
+	Cmd.Flags().StringVar(&format, "format", "report", "output format: report (table or JSON per --output) or sarif")
+}
@@ -0,0 +1,11 @@
+package git
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "git",
+	Short: "Query tags of any git repository",
+	Long:  "Query the tags of any git repository served over smart HTTP (cgit, Bitbucket, Sourcehut, self-hosted servers, ...) without cloning it.",
+}
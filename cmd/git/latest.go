@@ -0,0 +1,41 @@
+package git
+
+import (
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/git"
+	"github.com/spf13/cobra"
+)
+
+var latestCmd = &cobra.Command{
+	Use:   "latest <url>",
+	Short: "Show the latest version tag of a git repository",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		repoURL := args[0]
+
+		client := git.NewClient()
+		version, err := client.GetLatestVersion(repoURL)
+		if err != nil {
+			return err
+		}
+
+		output := &formatter.LatestOutput{
+			Package: repoURL,
+			Version: version,
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		return f.Format(output)
+	},
+}
+
+func init() {
+	Cmd.AddCommand(latestCmd)
+}
@@ -0,0 +1,57 @@
+package git
+
+import (
+	"time"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/git"
+	"github.com/spf13/cobra"
+)
+
+var versionsCmd = &cobra.Command{
+	Use:   "versions <url>",
+	Short: "Show all tags of a git repository",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		repoURL := args[0]
+
+		client := git.NewClient()
+		tags, err := client.GetVersions(repoURL)
+		if err != nil {
+			return err
+		}
+
+		items := make([]formatter.GitTagItem, 0, len(tags))
+		for _, tag := range tags {
+			var date string
+			if !tag.Date.IsZero() {
+				date = tag.Date.Format(time.RFC3339)
+			}
+			items = append(items, formatter.GitTagItem{
+				Name:      tag.Name,
+				Commit:    tag.Commit,
+				Annotated: tag.Annotated,
+				Date:      date,
+			})
+		}
+
+		output := &formatter.GitVersionsOutput{
+			Repository: git.NormalizeURL(repoURL),
+			Tags:       items,
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		return f.Format(output)
+	},
+}
+
+func init() {
+	Cmd.AddCommand(versionsCmd)
+}
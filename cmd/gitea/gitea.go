@@ -0,0 +1,38 @@
+package gitea
+
+import (
+	"github.com/acidghost/a555pq/internal/gitea"
+	"github.com/spf13/cobra"
+)
+
+// Cmd queries any Gitea or Forgejo instance, codeberg.org unless --host,
+// GITEA_HOST or FORGEJO_HOST names another.
+var Cmd = newForgeCmd("gitea", []string{"forgejo"}, "")
+
+// CodebergCmd always queries codeberg.org.
+var CodebergCmd = newForgeCmd("codeberg", nil, gitea.DefaultHost)
+
+// newForgeCmd builds a command group for Gitea-compatible forges. A non-empty
+// pinnedHost is always queried and no --host flag is offered.
+func newForgeCmd(use string, aliases []string, pinnedHost string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   "Query Gitea, Forgejo and Codeberg repositories",
+		Long:    "Query repository information from Gitea-compatible forges such as Codeberg, Forgejo and Gitea instances.",
+	}
+
+	host := pinnedHost
+	if pinnedHost == "" {
+		cmd.PersistentFlags().StringVar(&host, "host", "", "Forge host (defaults to GITEA_HOST, FORGEJO_HOST or codeberg.org)")
+	} else {
+		cmd.Short = "Query Codeberg repositories"
+		cmd.Long = "Query repository information from Codeberg."
+	}
+
+	cmd.AddCommand(newBrowseCmd(&host))
+	cmd.AddCommand(newLatestCmd(&host))
+	cmd.AddCommand(newShowCmd(&host))
+	cmd.AddCommand(newVersionsCmd(&host))
+	return cmd
+}
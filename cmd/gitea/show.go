@@ -0,0 +1,69 @@
+package gitea
+
+import (
+	"strings"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/gitea"
+	"github.com/spf13/cobra"
+)
+
+func newShowCmd(host *string) *cobra.Command {
+	var rawOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "show <owner/repo>",
+		Short: "Show all info of a repository",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			repoName := args[0]
+
+			client := gitea.NewClient(*host)
+			repo, err := client.GetPackageInfo(repoName)
+			if err != nil {
+				return err
+			}
+
+			if rawOutput {
+				f := formatter.NewJSONFormatter()
+				return f.Format(repo)
+			}
+
+			license := strings.Join(repo.Licenses, ", ")
+
+			latestVersion, err := client.GetLatestVersion(repoName)
+			if err != nil {
+				latestVersion = repo.DefaultBranch
+			}
+
+			homePage := repo.Website
+			if homePage == "" {
+				homePage = repo.HTMLURL
+			}
+
+			output := &formatter.ShowOutput{
+				Name:         repo.FullName,
+				Version:      latestVersion,
+				Description:  repo.Description,
+				Author:       repo.Owner.Login,
+				AuthorEmail:  "",
+				License:      license,
+				HomePage:     homePage,
+				Dependencies: []string{},
+			}
+
+			var f formatter.OutputFormatter
+			if shared.OutputFormat == shared.JSON {
+				f = formatter.NewJSONFormatter()
+			} else {
+				f = formatter.NewTableFormatter()
+			}
+
+			return f.Format(output)
+		},
+	}
+
+	cmd.Flags().BoolVar(&rawOutput, "raw", false, "Output raw JSON from the forge")
+	return cmd
+}
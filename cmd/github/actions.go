@@ -0,0 +1,132 @@
+package github
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pinActions           bool
+	actionsMinReleaseAge time.Duration
+	actionsAllowUndated  bool
+)
+
+var actionsCmd = &cobra.Command{
+	Use:   "actions [path]",
+	Short: "Audit GitHub Actions references in workflow files",
+	Long: "Audit the \"uses:\" references of workflow and composite action files. For every reference, " +
+		"report whether it is pinned to a full commit SHA, which tags or branches that commit corresponds to, " +
+		"and the newest release within the same major version. The path may be a file or a directory " +
+		"searched recursively and defaults to .github.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		root := ".github"
+		if len(args) > 0 {
+			root = args[0]
+		}
+
+		files, err := github.FindWorkflowFiles(root)
+		if err != nil {
+			return err
+		}
+
+		contents := make(map[string][]byte)
+		var refs []github.ActionRef
+		for _, file := range files {
+			data, err := os.ReadFile(file) //nolint:gosec
+			if err != nil {
+				return err
+			}
+			contents[file] = data
+			refs = append(refs, github.ParseActionRefs(file, data)...)
+		}
+
+		client := github.NewClient(false)
+		audits := client.AuditActions(refs, actionsMinReleaseAge, actionsAllowUndated)
+
+		if pinActions {
+			if err := pinFiles(files, contents, audits); err != nil {
+				return err
+			}
+		}
+
+		failed := 0
+		output := &formatter.ActionsOutput{Actions: make([]formatter.ActionItem, 0, len(audits))}
+		for _, a := range audits {
+			item := formatter.ActionItem{
+				Location:      fmt.Sprintf("%s:%d", a.File, a.Line),
+				Action:        a.Action(),
+				Ref:           a.Ref,
+				Pinned:        a.Pinned,
+				SHA:           a.SHA,
+				ResolvesTo:    a.Matches,
+				LatestInMajor: a.Latest,
+			}
+			if a.Err != nil {
+				item.Error = a.Err.Error()
+				failed++
+			}
+			output.Actions = append(output.Actions, item)
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		if err := f.Format(output); err != nil {
+			return err
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d references could not be resolved", failed, len(audits))
+		}
+		return nil
+	},
+}
+
+func pinFiles(files []string, contents map[string][]byte, audits []github.ActionAudit) error {
+	byFile := make(map[string][]github.ActionAudit)
+	for _, a := range audits {
+		byFile[a.File] = append(byFile[a.File], a)
+	}
+
+	for _, file := range files {
+		if len(byFile[file]) == 0 {
+			continue
+		}
+		pinned := github.PinActionRefs(contents[file], byFile[file])
+		if string(pinned) == string(contents[file]) {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(file, pinned, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to pin %s: %w", file, err)
+		}
+		fmt.Fprintf(os.Stderr, "Pinned %s\n", file)
+	}
+	return nil
+}
+
+func init() {
+	actionsCmd.Flags().BoolVar(&pinActions, "pin", false, "Rewrite references to full commit SHAs with a version comment")
+	actionsCmd.Flags().VarP(
+		shared.NewTimespanValue(&actionsMinReleaseAge),
+		"min-release-age",
+		"",
+		"ignore releases published within this timespan when looking for the newest release (e.g. 7d, 6mo, 1y)",
+	)
+	actionsCmd.Flags().BoolVar(&actionsAllowUndated, "allow-undated", false, "consider releases whose tag has no date despite --min-release-age")
+	Cmd.AddCommand(actionsCmd)
+}
@@ -1,41 +1,148 @@
 package github
 
 import (
+	"fmt"
+
 	"github.com/acidghost/a555pq/cmd/shared"
 	"github.com/acidghost/a555pq/internal/formatter"
 	"github.com/acidghost/a555pq/internal/github"
 	"github.com/spf13/cobra"
 )
 
+var (
+	reposFile     string
+	batchSize     int
+	requireSigned bool
+	components    bool
+)
+
 var latestCmd = &cobra.Command{
-	Use:   "latest <owner/repo>",
-	Short: "Show latest version of a repository",
-	Args:  cobra.ExactArgs(1),
+	Use:   "latest <owner/repo>...",
+	Short: "Show latest version of one or more repositories",
+	Long: "Show latest version of one or more repositories. When several repositories are given, " +
+		"as arguments or with --file, they are queried in batches and results are printed as each batch completes.",
 	RunE: func(_ *cobra.Command, args []string) error {
-		repoName := args[0]
+		repos := args
+		if reposFile != "" {
+			lines, err := shared.ReadLines(reposFile)
+			if err != nil {
+				return err
+			}
+			repos = append(repos, lines...)
+		}
 
-		client := github.NewClient(false)
-		version, err := client.GetLatestVersion(repoName)
+		matcher, err := tagMatcher()
 		if err != nil {
 			return err
 		}
+		opts := github.LatestOptions{RequireSigned: requireSigned, Tags: matcher}
 
-		output := &formatter.LatestOutput{
-			Package: repoName,
-			Version: version,
+		switch {
+		case len(repos) == 0:
+			return fmt.Errorf("requires at least one repository argument or --file")
+		case components && len(repos) > 1:
+			return fmt.Errorf("--components takes a single repository")
+		case components:
+			return showComponents(repos[0], matcher)
+		case len(repos) == 1 && reposFile == "":
+			return showLatest(repos[0], opts)
+		default:
+			return showLatestBatch(repos, opts)
 		}
+	},
+}
 
-		var f formatter.OutputFormatter
-		if shared.OutputFormat == shared.JSON {
-			f = formatter.NewJSONFormatter()
-		} else {
-			f = formatter.NewTableFormatter()
-		}
+func showLatest(repoName string, opts github.LatestOptions) error {
+	client := github.NewClient(false)
+	version, err := client.GetLatestVersionWithOptions(repoName, opts)
+	if err != nil {
+		return err
+	}
+
+	output := &formatter.LatestOutput{
+		Package: repoName,
+		Version: version,
+	}
+
+	var f formatter.OutputFormatter
+	if shared.OutputFormat == shared.JSON {
+		f = formatter.NewJSONFormatter()
+	} else {
+		f = formatter.NewTableFormatter()
+	}
+
+	return f.Format(output)
+}
 
+func showLatestBatch(repos []string, opts github.LatestOptions) error {
+	var f formatter.OutputFormatter
+	if shared.OutputFormat == shared.JSON {
+		f = formatter.NewJSONLinesFormatter()
+	} else {
+		f = formatter.NewTableFormatter()
+	}
+
+	failed := 0
+	client := github.NewClient(false)
+	err := client.GetLatestVersions(repos, batchSize, opts, func(results []github.LatestResult) error {
+		output := &formatter.LatestBatchOutput{Results: make([]formatter.LatestBatchItem, len(results))}
+		for i, r := range results {
+			output.Results[i] = formatter.LatestBatchItem{Package: r.Repository, Version: r.Version}
+			if r.Err != nil {
+				output.Results[i].Error = r.Err.Error()
+				failed++
+			}
+		}
 		return f.Format(output)
-	},
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d repositories failed", failed, len(repos))
+	}
+	return nil
+}
+
+func showComponents(repoName string, matcher *github.TagMatcher) error {
+	client := github.NewClient(false)
+	components, err := client.GetComponents(repoName, matcher)
+	if err != nil {
+		return err
+	}
+	if len(components) == 0 {
+		return fmt.Errorf("no versioned components found for repository '%s'", repoName)
+	}
+
+	output := &formatter.ComponentsOutput{
+		Repository: repoName,
+		Components: make([]formatter.ComponentItem, len(components)),
+	}
+	for i, c := range components {
+		output.Components[i] = formatter.ComponentItem{
+			Component:  c.Name,
+			Version:    c.Version,
+			Tag:        c.Tag,
+			UploadDate: c.Date,
+		}
+	}
+
+	var f formatter.OutputFormatter
+	if shared.OutputFormat == shared.JSON {
+		f = formatter.NewJSONFormatter()
+	} else {
+		f = formatter.NewTableFormatter()
+	}
+
+	return f.Format(output)
 }
 
 func init() {
+	latestCmd.Flags().StringVarP(&reposFile, "file", "f", "", "Read repositories from a file, one owner/repo per line ('-' for stdin)")
+	latestCmd.Flags().IntVar(&batchSize, "batch-size", github.DefaultBatchSize, "Number of repositories per GraphQL query")
+	latestCmd.Flags().BoolVar(&requireSigned, "require-signed", false, "Skip versions whose tag or release commit has no valid signature (requires GraphQL)")
+	latestCmd.Flags().BoolVar(&components, "components", false, "List every component of a monorepo with its latest version, splitting tags like 'cli/v1.4.0' or 'pkg@2.1.0'")
+	addTagFlags(latestCmd)
 	Cmd.AddCommand(latestCmd)
 }
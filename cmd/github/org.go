@@ -0,0 +1,69 @@
+package github
+
+import (
+	"time"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var orgFilter github.OrgFilter
+
+var orgCmd = &cobra.Command{
+	Use:   "org <org|user>",
+	Short: "List the repositories of an organisation or user with their latest release",
+	Long: "List every repository of an organisation or user account with its visibility, archived flag, " +
+		"latest release and its date, days since the last push and default branch. Requires a GitHub token.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		owner := args[0]
+
+		client := github.NewClient(false)
+		repos, err := client.GetOrgRepositories(owner, orgFilter)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		output := &formatter.OrgOutput{
+			Owner:        owner,
+			Repositories: make([]formatter.OrgRepoItem, len(repos)),
+		}
+		for i, repo := range repos {
+			item := formatter.OrgRepoItem{
+				Name:          repo.Name,
+				Visibility:    repo.Visibility,
+				Archived:      repo.Archived,
+				LatestRelease: repo.LatestRelease,
+				DaysSincePush: -1,
+				DefaultBranch: repo.DefaultBranch,
+			}
+			if !repo.ReleasedAt.IsZero() {
+				item.ReleaseDate = repo.ReleasedAt.Format(time.RFC3339)
+			}
+			if !repo.PushedAt.IsZero() {
+				item.DaysSincePush = int(now.Sub(repo.PushedAt).Hours() / 24)
+			}
+			output.Repositories[i] = item
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		return f.Format(output)
+	},
+}
+
+func init() {
+	orgCmd.Flags().StringSliceVar(&orgFilter.Topics, "topic", nil, "Only list repositories with this topic (repeatable, all must match)")
+	orgCmd.Flags().StringVar(&orgFilter.Language, "language", "", "Only list repositories with this primary language")
+	orgCmd.Flags().BoolVar(&orgFilter.ExcludeArchived, "exclude-archived", false, "Skip archived repositories")
+	orgCmd.Flags().BoolVar(&orgFilter.NoRelease, "no-release", false, "Only list repositories that have never published a release")
+	Cmd.AddCommand(orgCmd)
+}
@@ -0,0 +1,124 @@
+package github
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var packageType string
+
+var packagesCmd = &cobra.Command{
+	Use:   "packages <owner>",
+	Short: "List the GitHub Packages of an organisation or user",
+	Long: "List the GitHub Packages (npm, Maven, RubyGems, NuGet, container images, ...) of an organisation or user " +
+		"with the latest version of each. Requires a token with the read:packages scope.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		owner := args[0]
+
+		client := github.NewClient(false)
+		packages, err := client.GetPackages(owner, packageType)
+		if err != nil {
+			return err
+		}
+
+		output := &formatter.PackagesOutput{
+			Owner:    owner,
+			Packages: make([]formatter.PackageItem, len(packages)),
+		}
+		latest := client.GetLatestPackageVersions(packages)
+		for i, pkg := range packages {
+			item := formatter.PackageItem{
+				Name:       pkg.Name,
+				Type:       pkg.PackageType,
+				Visibility: pkg.Visibility,
+				Created:    pkg.CreatedAt.Format(time.RFC3339),
+			}
+			if pkg.Repository != nil {
+				item.Repository = pkg.Repository.FullName
+			}
+			if err := latest[i].Err; err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			} else if v := latest[i].Version; v != nil {
+				item.LatestVersion = v.Name
+				item.Tags = v.Tags()
+			}
+			output.Packages[i] = item
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		return f.Format(output)
+	},
+}
+
+var packagesShowCmd = &cobra.Command{
+	Use:   "show <owner>/<type>/<name>",
+	Short: "Show a GitHub Package and its versions",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		owner, pkgType, name, err := github.ParsePackagePath(args[0])
+		if err != nil {
+			return err
+		}
+
+		client := github.NewClient(false)
+		pkg, err := client.GetPackage(owner, pkgType, name)
+		if err != nil {
+			return err
+		}
+		versions, err := client.GetPackageVersions(*pkg, 0)
+		if err != nil {
+			return err
+		}
+
+		output := &formatter.PackageShowOutput{
+			Name:         pkg.Name,
+			Type:         pkg.PackageType,
+			Owner:        pkg.Owner.Login,
+			Visibility:   pkg.Visibility,
+			URL:          pkg.HTMLURL,
+			VersionCount: pkg.VersionCount,
+			Created:      pkg.CreatedAt.Format(time.RFC3339),
+			Updated:      pkg.UpdatedAt.Format(time.RFC3339),
+			Versions:     make([]formatter.PackageVersionItem, len(versions)),
+		}
+		if pkg.Repository != nil {
+			output.Repository = pkg.Repository.FullName
+		}
+		for i, v := range versions {
+			output.Versions[i] = formatter.PackageVersionItem{
+				Version: v.Name,
+				Tags:    v.Tags(),
+				Created: v.CreatedAt.Format(time.RFC3339),
+			}
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		return f.Format(output)
+	},
+}
+
+func init() {
+	packagesCmd.Flags().StringVar(&packageType, "type", "", "Only list packages of this type ("+strings.Join(github.PackageTypes, "|")+")")
+	packagesCmd.AddCommand(packagesShowCmd)
+	Cmd.AddCommand(packagesCmd)
+}
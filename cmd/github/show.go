@@ -1,18 +1,30 @@
 package github
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/acidghost/a555pq/cmd/shared"
 	"github.com/acidghost/a555pq/internal/formatter"
 	"github.com/acidghost/a555pq/internal/github"
+	"github.com/acidghost/a555pq/internal/manifest"
 	"github.com/spf13/cobra"
 )
 
-var rawOutput bool
+var (
+	rawOutput bool
+	noDeps    bool
+	depScopes []string
+	sbomPath  string
+)
 
 var showCmd = &cobra.Command{
 	Use:   "show <owner/repo>",
 	Short: "Show all info of a repository",
-	Args:  cobra.ExactArgs(1),
+	Long: "Show all info of a repository, along with its direct dependencies from the dependency graph SBOM " +
+		"or, when that is unavailable, its top-level manifests. --no-deps skips the requests they take.",
+	Args: cobra.ExactArgs(1),
 	RunE: func(_ *cobra.Command, args []string) error {
 		repoName := args[0]
 
@@ -38,6 +50,18 @@ var showCmd = &cobra.Command{
 		}
 
 		dependencies := []string{}
+		if !noDeps {
+			deps, err := getDependencies(client, repoName)
+			if err != nil && sbomPath != "" {
+				return err
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+			for _, dep := range github.FilterDependencies(deps, depScopes) {
+				dependencies = append(dependencies, dependencyString(dep))
+			}
+		}
 
 		output := &formatter.ShowOutput{
 			Name:         repo.FullName,
@@ -61,7 +85,57 @@ var showCmd = &cobra.Command{
 	},
 }
 
+// getDependencies summarises the repository's direct dependencies from the
+// dependency graph SBOM, writing the SBOM to --sbom when requested. When the
+// dependency graph is unavailable it falls back to parsing the top-level
+// manifests, unless the SBOM was requested.
+func getDependencies(client *github.Client, repoName string) ([]manifest.Dependency, error) {
+	sbom, err := client.GetSBOM(repoName)
+	if err == nil {
+		if sbomPath != "" {
+			if werr := os.WriteFile(sbomPath, sbom, 0o644); werr != nil { //nolint:gosec
+				return nil, fmt.Errorf("failed to write SBOM: %w", werr)
+			}
+		}
+		return github.SBOMDependencies(sbom)
+	}
+
+	if sbomPath != "" {
+		return nil, fmt.Errorf("SBOM not written: %w", err)
+	}
+
+	deps, merr := client.GetManifestDependencies(repoName)
+	if merr != nil {
+		return nil, fmt.Errorf("%w; manifest fallback: %w", err, merr)
+	}
+	return deps, nil
+}
+
+func dependencyString(d manifest.Dependency) string {
+	var b strings.Builder
+	b.WriteString(d.Name)
+	if d.Requirements != "" {
+		b.WriteString(" ")
+		b.WriteString(d.Requirements)
+	}
+	b.WriteString(" (")
+	b.WriteString(d.Ecosystem)
+	if d.Scope != "" && d.Scope != manifest.Runtime {
+		b.WriteString(",")
+		b.WriteString(d.Scope)
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
 func init() {
 	showCmd.Flags().BoolVar(&rawOutput, "raw", false, "Output raw JSON from GitHub")
+	showCmd.Flags().BoolVar(&noDeps, "no-deps", false, "Do not list the repository's direct dependencies")
+	showCmd.Flags().StringSliceVar(&depScopes, "scope", nil, "Only list dependencies of these ecosystems (e.g. npm,golang)")
+	showCmd.Flags().StringVar(&sbomPath, "sbom", "", "Write the dependency graph SPDX document to this file")
+	showCmd.MarkFlagsMutuallyExclusive("raw", "scope")
+	showCmd.MarkFlagsMutuallyExclusive("raw", "sbom")
+	showCmd.MarkFlagsMutuallyExclusive("no-deps", "scope")
+	showCmd.MarkFlagsMutuallyExclusive("no-deps", "sbom")
 	Cmd.AddCommand(showCmd)
 }
@@ -0,0 +1,22 @@
+package github
+
+import (
+	"github.com/acidghost/a555pq/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tagPrefix  string
+	tagPattern string
+)
+
+// addTagFlags registers the monorepo tag selection flags on cmd.
+func addTagFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&tagPrefix, "tag-prefix", "", "Only consider tags starting with this prefix, e.g. 'cli/v'")
+	cmd.Flags().StringVar(&tagPattern, "tag-pattern", "", "Only consider tags matching this regular expression; its first (or 'version' named) group is the version")
+	cmd.MarkFlagsMutuallyExclusive("tag-prefix", "tag-pattern")
+}
+
+func tagMatcher() (*github.TagMatcher, error) {
+	return github.NewTagMatcher(tagPrefix, tagPattern)
+}
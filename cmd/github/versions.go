@@ -9,7 +9,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var useRest bool
+var (
+	useRest        bool
+	showSignatures bool
+)
 
 var versionsCmd = &cobra.Command{
 	Use:   "versions <owner/repo>",
@@ -18,15 +21,26 @@ var versionsCmd = &cobra.Command{
 	RunE: func(_ *cobra.Command, args []string) error {
 		repoName := args[0]
 
-		client := github.NewClient(useRest)
-		versions, err := client.GetVersions(repoName)
+		matcher, err := tagMatcher()
 		if err != nil {
 			return err
 		}
 
-		sort.Slice(versions, func(i, j int) bool {
-			return versions[i].UploadDate > versions[j].UploadDate
+		client := github.NewClient(useRest)
+		versions, err := client.ListVersions(repoName, github.VersionsOptions{
+			Signatures: showSignatures,
+			Tags:       matcher,
 		})
+		if err != nil {
+			return err
+		}
+
+		// Matched tags are already ordered by the version extracted from them.
+		if matcher == nil {
+			sort.Slice(versions, func(i, j int) bool {
+				return versions[i].UploadDate > versions[j].UploadDate
+			})
+		}
 
 		output := &formatter.VersionsOutput{
 			Package:  repoName,
@@ -46,5 +60,7 @@ var versionsCmd = &cobra.Command{
 
 func init() {
 	versionsCmd.Flags().BoolVar(&useRest, "rest", false, "Use REST API instead of GraphQL (for unauthenticated requests)")
+	versionsCmd.Flags().BoolVar(&showSignatures, "signatures", false, "Show the signature verification status of tags and release commits (requires GraphQL)")
+	addTagFlags(versionsCmd)
 	Cmd.AddCommand(versionsCmd)
 }
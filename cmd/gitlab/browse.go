@@ -0,0 +1,46 @@
+package gitlab
+
+import (
+	"fmt"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/gitlab"
+	"github.com/spf13/cobra"
+)
+
+var browseCmd = &cobra.Command{
+	Use:   "browse <group/project>",
+	Short: "Open project page on GitLab in browser",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		projectName := args[0]
+		url := fmt.Sprintf("https://%s/%s", gitlab.ResolveHost(host), projectName)
+
+		opened := shared.OpenBrowser(url)
+
+		if !opened {
+			fmt.Println(url)
+			return nil
+		}
+
+		output := &formatter.BrowseOutput{
+			Package: projectName,
+			URL:     url,
+			Opened:  opened,
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		return f.Format(output)
+	},
+}
+
+func init() {
+	Cmd.AddCommand(browseCmd)
+}
@@ -0,0 +1,17 @@
+package gitlab
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var host string
+
+var Cmd = &cobra.Command{
+	Use:   "gitlab",
+	Short: "Query GitLab projects",
+	Long:  "Query project information from gitlab.com or a self-managed GitLab instance.",
+}
+
+func init() {
+	Cmd.PersistentFlags().StringVar(&host, "host", "", "GitLab host (defaults to GITLAB_HOST or gitlab.com)")
+}
@@ -0,0 +1,41 @@
+package gitlab
+
+import (
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/gitlab"
+	"github.com/spf13/cobra"
+)
+
+var latestCmd = &cobra.Command{
+	Use:   "latest <group/project>",
+	Short: "Show latest version of a project",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		projectName := args[0]
+
+		client := gitlab.NewClient(host)
+		version, err := client.GetLatestVersion(projectName)
+		if err != nil {
+			return err
+		}
+
+		output := &formatter.LatestOutput{
+			Package: projectName,
+			Version: version,
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		return f.Format(output)
+	},
+}
+
+func init() {
+	Cmd.AddCommand(latestCmd)
+}
@@ -0,0 +1,70 @@
+package gitlab
+
+import (
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/gitlab"
+	"github.com/spf13/cobra"
+)
+
+var rawOutput bool
+
+var showCmd = &cobra.Command{
+	Use:   "show <group/project>",
+	Short: "Show all info of a project",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		projectName := args[0]
+
+		client := gitlab.NewClient(host)
+		project, err := client.GetPackageInfo(projectName)
+		if err != nil {
+			return err
+		}
+
+		if rawOutput {
+			f := formatter.NewJSONFormatter()
+			return f.Format(project)
+		}
+
+		var license string
+		if project.License != nil {
+			license = project.License.Name
+		}
+
+		latestVersion, err := client.GetLatestVersion(projectName)
+		if err != nil {
+			latestVersion = project.DefaultBranch
+		}
+
+		author := project.Namespace.FullPath
+		if project.Owner != nil {
+			author = project.Owner.Username
+		}
+
+		output := &formatter.ShowOutput{
+			Name:         project.PathWithNamespace,
+			Version:      latestVersion,
+			Description:  project.Description,
+			Author:       author,
+			AuthorEmail:  "",
+			License:      license,
+			HomePage:     project.WebURL,
+			Dependencies: []string{},
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		return f.Format(output)
+	},
+}
+
+func init() {
+	showCmd.Flags().BoolVar(&rawOutput, "raw", false, "Output raw JSON from GitLab")
+	Cmd.AddCommand(showCmd)
+}
@@ -0,0 +1,47 @@
+package gitlab
+
+import (
+	"sort"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/gitlab"
+	"github.com/spf13/cobra"
+)
+
+var versionsCmd = &cobra.Command{
+	Use:   "versions <group/project>",
+	Short: "Show all versions of a project",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		projectName := args[0]
+
+		client := gitlab.NewClient(host)
+		versions, err := client.GetVersions(projectName)
+		if err != nil {
+			return err
+		}
+
+		sort.Slice(versions, func(i, j int) bool {
+			return versions[i].UploadDate > versions[j].UploadDate
+		})
+
+		output := &formatter.VersionsOutput{
+			Package:  projectName,
+			Versions: versions,
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		return f.Format(output)
+	},
+}
+
+func init() {
+	Cmd.AddCommand(versionsCmd)
+}
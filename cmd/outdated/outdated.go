@@ -0,0 +1,81 @@
+package outdated
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/manifest"
+	"github.com/acidghost/a555pq/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	concurrency   int
+	minReleaseAge time.Duration
+)
+
+var Cmd = &cobra.Command{
+	Use:   "outdated [path]",
+	Short: "Show outdated dependencies of a project",
+	Long: "Show the dependencies declared by the manifests and lockfiles of a project, given as a directory " +
+		"(the current one by default) or a single file. For every dependency it reports the version in use, " +
+		"the highest version its requirement allows (wanted) and the highest version overall (latest), with " +
+		"the age of each. Versions locked by a lockfile take precedence over those pinned by a manifest. " +
+		"Supported files: " + strings.Join(manifest.Supported(), ", ") + ".",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		deps, files, err := manifest.Load(path)
+		if err != nil {
+			return err
+		}
+
+		options := registry.OutdatedOptions{
+			Options:     registry.Options{MinReleaseAge: minReleaseAge},
+			Concurrency: concurrency,
+		}
+		output := &formatter.OutdatedOutput{
+			Files:        files,
+			Dependencies: registry.Outdated(context.Background(), deps, options),
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+		if err := f.Format(output); err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, d := range output.Dependencies {
+			if d.Error != "" {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d dependencies failed", failed, len(deps))
+		}
+		return nil
+	},
+}
+
+func init() {
+	Cmd.Flags().IntVar(&concurrency, "concurrency", registry.DefaultOutdatedConcurrency, "Number of registry requests to run at once")
+	Cmd.Flags().VarP(
+		shared.NewTimespanValue(&minReleaseAge),
+		"min-release-age",
+		"",
+		"ignore versions released within this timespan when selecting the wanted and latest versions (e.g. 7d, 6mo, 1y); ecosystems without release timestamps are unaffected",
+	)
+}
@@ -0,0 +1,79 @@
+package purl
+
+import (
+	"fmt"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/container"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/registry"
+	"github.com/git-pkgs/registries"
+	"github.com/spf13/cobra"
+)
+
+var browseCmd = &cobra.Command{
+	Use:   "browse <purl>",
+	Short: "Open the web page of the package a Package URL refers to in browser",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		p, err := parse(args[0])
+		if err != nil {
+			return err
+		}
+
+		url, err := browseURL(p)
+		if err != nil {
+			return err
+		}
+
+		opened := shared.OpenBrowser(url)
+
+		if !opened {
+			fmt.Println(url)
+			return nil
+		}
+
+		output := &formatter.BrowseOutput{
+			Package: p.FullName(),
+			URL:     url,
+			Opened:  opened,
+			PURL:    p.String(),
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		return f.Format(output)
+	},
+}
+
+func browseURL(p *registries.PURL) (string, error) {
+	switch p.Type {
+	case "docker":
+		image, err := container.ImageFromPURL(p.WithoutVersion())
+		if err != nil {
+			return "", err
+		}
+		return container.NewClient().GetBrowseURL(image)
+	case "github":
+		return fmt.Sprintf("https://github.com/%s", p.FullName()), nil
+	}
+
+	client, name, _, err := registry.NewFromPURL(p.String())
+	if err != nil {
+		return "", err
+	}
+	url := client.BrowseURL(name)
+	if url == "" {
+		return "", fmt.Errorf("browse URL not available for %s", p.Type)
+	}
+	return url, nil
+}
+
+func init() {
+	Cmd.AddCommand(browseCmd)
+}
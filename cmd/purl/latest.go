@@ -0,0 +1,89 @@
+package purl
+
+import (
+	"context"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/container"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/github"
+	"github.com/acidghost/a555pq/internal/registry"
+	"github.com/git-pkgs/registries"
+	"github.com/spf13/cobra"
+)
+
+var latestCmd = &cobra.Command{
+	Use:   "latest <purl>",
+	Short: "Show the latest version of the package a Package URL refers to",
+	Long:  "Show the latest version of the package a Package URL refers to. The version in the Package URL, if any, is ignored.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		p, err := parse(args[0])
+		if err != nil {
+			return err
+		}
+
+		var output any
+		switch p.Type {
+		case "docker":
+			output, err = latestImage(p)
+		case "github":
+			output, err = latestRepository(p)
+		default:
+			output, err = latestPackage(p)
+		}
+		if err != nil {
+			return err
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		return f.Format(output)
+	},
+}
+
+func latestPackage(p *registries.PURL) (*formatter.LatestOutput, error) {
+	client, name, _, err := registry.NewFromPURL(p.String())
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.Latest(context.Background(), name, registry.Options{})
+	if err != nil {
+		return nil, err
+	}
+	output.PURL = p.String()
+	return output, nil
+}
+
+func latestImage(p *registries.PURL) (*formatter.ContainerLatestOutput, error) {
+	image, err := container.ImageFromPURL(p.WithoutVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := container.NewClient().GetLatestTag(image)
+	if err != nil {
+		return nil, err
+	}
+	return &formatter.ContainerLatestOutput{Image: image, Version: tag, PURL: p.String()}, nil
+}
+
+func latestRepository(p *registries.PURL) (*formatter.LatestOutput, error) {
+	repoName := p.FullName()
+
+	version, err := github.NewClient(false).GetLatestVersion(repoName)
+	if err != nil {
+		return nil, err
+	}
+	return &formatter.LatestOutput{Package: repoName, Version: version, PURL: p.String()}, nil
+}
+
+func init() {
+	Cmd.AddCommand(latestCmd)
+}
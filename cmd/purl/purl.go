@@ -0,0 +1,29 @@
+package purl
+
+import (
+	"fmt"
+
+	"github.com/git-pkgs/registries"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "purl",
+	Short: "Query packages by Package URL",
+	Long: "Query packages by Package URL (purl), e.g. pkg:npm/%40babel/core@7.24.0. " +
+		"pkg:docker URLs are looked up in container registries and pkg:github URLs on GitHub; " +
+		"every other type in the registry of its ecosystem.",
+}
+
+// parse parses a package URL argument. The returned URL is in its canonical
+// form, which is what outputs report.
+func parse(arg string) (*registries.PURL, error) {
+	p, err := registries.ParsePURL(arg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid package URL '%s': %w", arg, err)
+	}
+	if p.Type == "github" && p.Namespace == "" {
+		return nil, fmt.Errorf("invalid package URL '%s': expected pkg:github/<owner>/<repo>", arg)
+	}
+	return p, nil
+}
@@ -0,0 +1,141 @@
+package purl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/container"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/github"
+	"github.com/acidghost/a555pq/internal/registry"
+	"github.com/git-pkgs/registries"
+	"github.com/spf13/cobra"
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show <purl>",
+	Short: "Show all info of the package a Package URL refers to",
+	Long:  "Show all info of the package a Package URL refers to, at the version it names or the latest one.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		p, err := parse(args[0])
+		if err != nil {
+			return err
+		}
+
+		var output any
+		switch p.Type {
+		case "docker":
+			output, err = showImage(p)
+		case "github":
+			output, err = showRepository(p)
+		default:
+			output, err = showPackage(p)
+		}
+		if err != nil {
+			return err
+		}
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		return f.Format(output)
+	},
+}
+
+func showPackage(p *registries.PURL) (*formatter.ShowOutput, error) {
+	client, name, version, err := registry.NewFromPURL(p.String())
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.Show(context.Background(), name, registry.Options{Version: version})
+	if err != nil {
+		return nil, err
+	}
+	for _, warning := range output.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	if output.Replacement != "" {
+		fmt.Fprintf(os.Stderr, "Warning: use %s instead\n", output.Replacement)
+	}
+	output.PURL = p.String()
+	return output, nil
+}
+
+func showImage(p *registries.PURL) (*formatter.ContainerShowOutput, error) {
+	image, err := container.ImageFromPURL(p)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := container.NewClient().GetImageInfo(image)
+	if err != nil {
+		return nil, err
+	}
+
+	var digest string
+	if info.Manifest != nil {
+		digest = info.Manifest.Digest
+	}
+
+	return &formatter.ContainerShowOutput{
+		Name:         info.Name,
+		Description:  info.Description,
+		Tag:          info.LatestTag,
+		TagDate:      info.TagDate,
+		TagSize:      info.Size,
+		Digest:       digest,
+		Registry:     info.Registry,
+		FullImageRef: info.FullImageRef,
+		PURL:         p.String(),
+	}, nil
+}
+
+func showRepository(p *registries.PURL) (*formatter.ShowOutput, error) {
+	repoName := p.FullName()
+
+	client := github.NewClient(false)
+	repo, err := client.GetPackageInfo(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	var license string
+	if repo.License != nil {
+		license = repo.License.Name
+	}
+
+	version := p.Version
+	if version == "" {
+		version, err = client.GetLatestVersion(repoName)
+		if errors.Is(err, github.ErrNoVersions) {
+			version, err = repo.DefaultBranch, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &formatter.ShowOutput{
+		Name:         repo.FullName,
+		Version:      version,
+		Description:  repo.Description,
+		Author:       repo.Owner.Login,
+		License:      license,
+		HomePage:     repo.Homepage,
+		Dependencies: []string{},
+		PURL:         p.String(),
+	}, nil
+}
+
+func init() {
+	Cmd.AddCommand(showCmd)
+}
@@ -0,0 +1,96 @@
+package purl
+
+import (
+	"context"
+	"sort"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/container"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/github"
+	"github.com/acidghost/a555pq/internal/registry"
+	"github.com/git-pkgs/registries"
+	"github.com/spf13/cobra"
+)
+
+var versionsCmd = &cobra.Command{
+	Use:   "versions <purl>",
+	Short: "Show all versions of the package a Package URL refers to",
+	Long:  "Show all versions of the package a Package URL refers to. The version in the Package URL, if any, is ignored.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		p, err := parse(args[0])
+		if err != nil {
+			return err
+		}
+
+		var output *formatter.VersionsOutput
+		switch p.Type {
+		case "docker":
+			output, err = imageVersions(p)
+		case "github":
+			output, err = repositoryVersions(p)
+		default:
+			output, err = packageVersions(p)
+		}
+		if err != nil {
+			return err
+		}
+		output.PURL = p.String()
+
+		var f formatter.OutputFormatter
+		if shared.OutputFormat == shared.JSON {
+			f = formatter.NewJSONFormatter()
+		} else {
+			f = formatter.NewTableFormatter()
+		}
+
+		return f.Format(output)
+	},
+}
+
+func packageVersions(p *registries.PURL) (*formatter.VersionsOutput, error) {
+	client, name, _, err := registry.NewFromPURL(p.String())
+	if err != nil {
+		return nil, err
+	}
+	return client.Versions(context.Background(), name, registry.Options{})
+}
+
+func imageVersions(p *registries.PURL) (*formatter.VersionsOutput, error) {
+	image, err := container.ImageFromPURL(p.WithoutVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := container.NewClient().GetTags(image)
+	if err != nil {
+		return nil, err
+	}
+
+	var versionItems []formatter.VersionItem
+	for _, tag := range tags {
+		versionItems = append(versionItems, formatter.VersionItem{
+			Version:    tag.Name,
+			UploadDate: tag.CreatedAt,
+		})
+	}
+	return &formatter.VersionsOutput{Package: image, Versions: versionItems}, nil
+}
+
+func repositoryVersions(p *registries.PURL) (*formatter.VersionsOutput, error) {
+	repoName := p.FullName()
+
+	versions, err := github.NewClient(false).ListVersions(repoName, github.VersionsOptions{})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].UploadDate > versions[j].UploadDate
+	})
+	return &formatter.VersionsOutput{Package: repoName, Versions: versions}, nil
+}
+
+func init() {
+	Cmd.AddCommand(versionsCmd)
+}
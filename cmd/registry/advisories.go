@@ -0,0 +1,71 @@
+package registry
+
+import (
+	"context"
+	"os"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/advisory"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+// advisorySource holds the flags selecting where advisories are read from.
+type advisorySource struct {
+	url string
+	dir string
+}
+
+// addFlags registers the advisory source flags on cmd. They default to the
+// A555PQ_OSV_URL and A555PQ_OSV_DIR environment variables.
+func (s *advisorySource) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&s.url, "osv-url", os.Getenv("A555PQ_OSV_URL"), "base URL of the OSV-compatible advisory API (default "+advisory.DefaultOSVURL+")")
+	cmd.Flags().StringVar(&s.dir, "osv-dir", os.Getenv("A555PQ_OSV_DIR"), "read advisories from a local OSV export directory instead of the API")
+}
+
+func (s *advisorySource) source() advisory.Source {
+	if s.dir != "" {
+		return advisory.NewDirSource(s.dir)
+	}
+	return advisory.NewOSVSource(s.url)
+}
+
+func newAdvisoriesCmd(ecosystem string) *cobra.Command {
+	var src advisorySource
+
+	cmd := &cobra.Command{
+		Use:   "advisories <package> [version]",
+		Short: "List security advisories affecting a package",
+		Long: "List the security advisories affecting a package from the OSV database, which includes GitHub's " +
+			"global advisory database, and whether they affect the given version or the latest one.",
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			client, err := registry.New(resolveEcosystem(ecosystem))
+			if err != nil {
+				return err
+			}
+
+			version := ""
+			if len(args) > 1 {
+				version = args[1]
+			}
+			output, err := client.Advisories(context.Background(), args[0], version, src.source())
+			if err != nil {
+				return err
+			}
+
+			var f formatter.OutputFormatter
+			if shared.OutputFormat == shared.JSON {
+				f = formatter.NewJSONFormatter()
+			} else {
+				f = formatter.NewTableFormatter()
+			}
+
+			return f.Format(output)
+		},
+	}
+
+	src.addFlags(cmd)
+	return cmd
+}
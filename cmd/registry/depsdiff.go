@@ -0,0 +1,38 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+func newDepsDiffCmd(ecosystem string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deps-diff <package> <from> <to>",
+		Short: "Show how dependencies changed between two versions of a package",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(_ *cobra.Command, args []string) error {
+			client, err := registry.New(resolveEcosystem(ecosystem))
+			if err != nil {
+				return err
+			}
+
+			output, err := client.DepsDiff(context.Background(), args[0], args[1], args[2])
+			if err != nil {
+				return err
+			}
+
+			var f formatter.OutputFormatter
+			if shared.OutputFormat == shared.JSON {
+				f = formatter.NewJSONFormatter()
+			} else {
+				f = formatter.NewTableFormatter()
+			}
+
+			return f.Format(output)
+		},
+	}
+}
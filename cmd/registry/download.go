@@ -0,0 +1,71 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+func newDownloadCmd(ecosystem string) *cobra.Command {
+	var (
+		dir             string
+		sdist           bool
+		wheel           string
+		allowUnverified bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "download <package>[@version]",
+		Short: "Download a package artifact and verify its integrity",
+		Long: "Download the artifact of a version from the registry and check it against the integrity hash the " +
+			"registry publishes. Nothing is written when the hashes differ. The latest version is downloaded " +
+			"unless one is given. Artifacts whose registry publishes no usable integrity, or only a weak sha1 or md5 one, are " +
+			"refused with their computed hash reported, unless --allow-unverified is given.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			name, version := registry.SplitPackageVersion(args[0])
+
+			client, err := registry.New(resolveEcosystem(ecosystem))
+			if err != nil {
+				return err
+			}
+			output, err := client.Download(context.Background(), name, registry.DownloadOptions{
+				Options:         registry.Options{Version: version},
+				Dir:             dir,
+				Sdist:           sdist,
+				Wheel:           wheel,
+				AllowUnverified: allowUnverified,
+			})
+			if err != nil {
+				return err
+			}
+			if !output.Verified {
+				fmt.Fprintf(os.Stderr, "Warning: %s was written without verifying its integrity\n", output.File)
+			}
+
+			var f formatter.OutputFormatter
+			if shared.OutputFormat == shared.JSON {
+				f = formatter.NewJSONFormatter()
+			} else {
+				f = formatter.NewTableFormatter()
+			}
+
+			return f.Format(output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&dir, "dir", "d", "", "directory to write the artifact to (default: the working directory)")
+	cmd.Flags().BoolVar(&allowUnverified, "allow-unverified", false, "write artifacts whose registry publishes no usable integrity or only a weak sha1 or md5 one")
+	if ecosystem == "pypi" {
+		cmd.Flags().BoolVar(&sdist, "sdist", false, "download the source distribution (the default when there is one)")
+		cmd.Flags().StringVar(&wheel, "wheel", "", "download the wheel for this platform tag (e.g. manylinux_2_17_x86_64, any) or full tag (e.g. cp312-cp312-win_amd64)")
+		cmd.MarkFlagsMutuallyExclusive("sdist", "wheel")
+	}
+
+	return cmd
+}
@@ -11,7 +11,13 @@ import (
 )
 
 func newLatestCmd(ecosystem string) *cobra.Command {
-	var minReleaseAge time.Duration
+	var (
+		minReleaseAge  time.Duration
+		skipVulnerable bool
+		src            advisorySource
+		strategy       = registry.StrategyHighest
+		versionRange   string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "latest <package>",
@@ -23,7 +29,10 @@ func newLatestCmd(ecosystem string) *cobra.Command {
 				return err
 			}
 
-			options := registry.Options{MinReleaseAge: minReleaseAge}
+			options := registry.Options{MinReleaseAge: minReleaseAge, Strategy: strategy, Range: versionRange}
+			if skipVulnerable {
+				options.SkipVulnerable = src.source()
+			}
 			output, err := client.Latest(context.Background(), args[0], options)
 			if err != nil {
 				return err
@@ -46,5 +55,9 @@ func newLatestCmd(ecosystem string) *cobra.Command {
 		"",
 		"ignore versions released within this timespan when selecting the latest (e.g. 7d, 6mo, 1y); ecosystems without release timestamps are unaffected",
 	)
+	cmd.Flags().StringVar(&versionRange, "range", "", "select the latest version within this range, in the ecosystem's syntax (e.g. '^4.17', '>=4.2,<5') or as a vers: string")
+	cmd.Flags().Var(&strategy, "strategy", "how to pick the latest version: highest (in the ecosystem's version ordering), newest (most recently published) or registry (as reported by the registry)")
+	cmd.Flags().BoolVar(&skipVulnerable, "skip-vulnerable", false, "skip versions affected by a known security advisory")
+	src.addFlags(cmd)
 	return cmd
 }
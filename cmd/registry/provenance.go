@@ -0,0 +1,43 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+func newProvenanceCmd(ecosystem string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "provenance <package>[@version]",
+		Short: "Show how a version was published and verify its registry signatures",
+		Long: "Show whether a version was published through trusted publishing, the source repository and workflow " +
+			"its Sigstore provenance bundle names, and whether each registry signature verifies against the " +
+			"registry's public keys. The latest version is shown unless one is given. The Sigstore bundle itself " +
+			"is not verified.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			name, version := registry.SplitPackageVersion(args[0])
+
+			client, err := registry.New(resolveEcosystem(ecosystem))
+			if err != nil {
+				return err
+			}
+			output, err := client.Provenance(context.Background(), name, registry.Options{Version: version})
+			if err != nil {
+				return err
+			}
+
+			var f formatter.OutputFormatter
+			if shared.OutputFormat == shared.JSON {
+				f = formatter.NewJSONFormatter()
+			} else {
+				f = formatter.NewTableFormatter()
+			}
+
+			return f.Format(output)
+		},
+	}
+}
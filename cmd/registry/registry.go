@@ -3,7 +3,9 @@ package registry
 import (
 	"fmt"
 
+	"github.com/acidghost/a555pq/internal/advisory"
 	"github.com/acidghost/a555pq/internal/registry"
+	"github.com/git-pkgs/registries"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +20,28 @@ var aliases = map[string]string{
 	"brew":      "homebrew",
 }
 
+// dependencyScopes maps the scope names show and tree accept to dependency
+// scopes.
+var dependencyScopes = map[string]registries.Scope{
+	"runtime":     registries.Runtime,
+	"development": registries.Development,
+	"test":        registries.Test,
+	"build":       registries.Build,
+	"optional":    registries.Optional,
+}
+
+func parseScopes(names []string) ([]registries.Scope, error) {
+	scopes := make([]registries.Scope, 0, len(names))
+	for _, name := range names {
+		scope, ok := dependencyScopes[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid scope '%s', expected runtime, development, test, build or optional", name)
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
+
 func resolveEcosystem(s string) string {
 	if aliased, ok := aliases[s]; ok {
 		return aliased
@@ -35,6 +59,15 @@ func RegisterCommands(root *cobra.Command) {
 		ecoCmd.AddCommand(newLatestCmd(eco))
 		ecoCmd.AddCommand(newVersionsCmd(eco))
 		ecoCmd.AddCommand(newBrowseCmd(eco))
+		ecoCmd.AddCommand(newTreeCmd(eco))
+		ecoCmd.AddCommand(newDepsDiffCmd(eco))
+		ecoCmd.AddCommand(newDownloadCmd(eco))
+		if _, ok := advisory.OSVEcosystem(eco); ok {
+			ecoCmd.AddCommand(newAdvisoriesCmd(eco))
+		}
+		if eco == "npm" {
+			ecoCmd.AddCommand(newProvenanceCmd(eco))
+		}
 		root.AddCommand(ecoCmd)
 	}
 }
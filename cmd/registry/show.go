@@ -2,6 +2,8 @@ package registry
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/acidghost/a555pq/cmd/shared"
@@ -11,20 +13,34 @@ import (
 )
 
 func newShowCmd(ecosystem string) *cobra.Command {
-	var minReleaseAge time.Duration
+	var (
+		minReleaseAge time.Duration
+		rawOutput     bool
+		field         string
+		version       string
+		scopes        []string
+	)
 
 	cmd := &cobra.Command{
-		Use:   "show <package>",
+		Use:   "show <package>[@version]",
 		Short: "Show all info of a package",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
-			client, err := registry.New(resolveEcosystem(ecosystem))
+			name, pinned := registry.SplitPackageVersion(args[0])
+			if pinned != "" && version != "" {
+				return fmt.Errorf("version given both as '%s' and --version", args[0])
+			}
+			if pinned != "" {
+				version = pinned
+			}
+
+			parsed, err := parseScopes(scopes)
 			if err != nil {
 				return err
 			}
+			options := registry.Options{MinReleaseAge: minReleaseAge, Version: version, Scopes: parsed}
 
-			options := registry.Options{MinReleaseAge: minReleaseAge}
-			output, err := client.Show(context.Background(), args[0], options)
+			client, err := registry.New(resolveEcosystem(ecosystem))
 			if err != nil {
 				return err
 			}
@@ -36,6 +52,32 @@ func newShowCmd(ecosystem string) *cobra.Command {
 				f = formatter.NewTableFormatter()
 			}
 
+			if rawOutput || field != "" {
+				raw, err := client.RawShow(context.Background(), name, options)
+				if err != nil {
+					return err
+				}
+				if rawOutput {
+					return formatter.NewJSONFormatter().Format(raw)
+				}
+
+				value, ok := registry.Field(raw, field)
+				if !ok {
+					return fmt.Errorf("field '%s' not found for package '%s'", field, name)
+				}
+				return f.Format(&formatter.FieldOutput{Package: name, Field: field, Value: value})
+			}
+
+			output, err := client.Show(context.Background(), name, options)
+			if err != nil {
+				return err
+			}
+			for _, warning := range output.Warnings {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+			}
+			if output.Replacement != "" {
+				fmt.Fprintf(os.Stderr, "Warning: use %s instead\n", output.Replacement)
+			}
 			return f.Format(output)
 		},
 	}
@@ -46,5 +88,10 @@ func newShowCmd(ecosystem string) *cobra.Command {
 		"",
 		"report and resolve dependencies against the newest version older than this timespan (e.g. 7d, 6mo, 1y); ecosystems without release timestamps are unaffected",
 	)
+	cmd.Flags().StringVar(&version, "version", "", "show this version instead of the latest one")
+	cmd.Flags().StringSliceVar(&scopes, "scope", nil, "list dependencies in these scopes: runtime (default), development, test, build, optional")
+	cmd.Flags().BoolVar(&rawOutput, "raw", false, "Output the full registry data, including registry-specific metadata")
+	cmd.Flags().StringVar(&field, "field", "", "print a single value of the registry data by dot-separated path (e.g. requires_python)")
+	cmd.MarkFlagsMutuallyExclusive("raw", "field")
 	return cmd
 }
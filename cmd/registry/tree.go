@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/acidghost/a555pq/cmd/shared"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/registry"
+	"github.com/git-pkgs/registries"
+	"github.com/spf13/cobra"
+)
+
+func newTreeCmd(ecosystem string) *cobra.Command {
+	var (
+		minReleaseAge time.Duration
+		depth         int
+		include       []string
+		format        string
+		concurrency   int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tree <package>[@version]",
+		Short: "Show the transitive dependency tree of a package",
+		Long: `Resolve the dependencies of a package recursively, each to the highest
+version matching its requirement, and print them as a tree. Packages that
+appear more than once only list their dependencies the first time.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			var f formatter.OutputFormatter
+			switch {
+			case format == "dot":
+				f = formatter.NewDOTFormatter()
+			case format == "mermaid":
+				f = formatter.NewMermaidFormatter()
+			case format != "tree":
+				return fmt.Errorf("invalid format '%s', expected tree, dot or mermaid", format)
+			case shared.OutputFormat == shared.JSON:
+				f = formatter.NewJSONFormatter()
+			default:
+				f = formatter.NewTableFormatter()
+			}
+
+			options := registry.TreeOptions{
+				Options: registry.Options{
+					MinReleaseAge: minReleaseAge,
+					Scopes:        []registries.Scope{registries.Runtime},
+				},
+				Depth:       depth,
+				Concurrency: concurrency,
+			}
+			included, err := parseScopes(include)
+			if err != nil {
+				return err
+			}
+			options.Scopes = append(options.Scopes, included...)
+
+			client, err := registry.New(resolveEcosystem(ecosystem))
+			if err != nil {
+				return err
+			}
+
+			name, version := registry.SplitPackageVersion(args[0])
+			output, err := client.Tree(context.Background(), name, version, options)
+			if err != nil {
+				return err
+			}
+			return f.Format(output)
+		},
+	}
+
+	cmd.Flags().VarP(
+		shared.NewTimespanValue(&minReleaseAge),
+		"min-release-age",
+		"",
+		"resolve every package to versions older than this timespan (e.g. 7d, 6mo, 1y); ecosystems without release timestamps are unaffected",
+	)
+	cmd.Flags().IntVar(&depth, "depth", 0, "limit the levels of dependencies resolved (0 for no limit)")
+	cmd.Flags().StringSliceVar(&include, "include", nil, "also follow these dependency scopes of the package: development, test, build, optional")
+	cmd.Flags().StringVar(&format, "format", "tree", "output format: tree (table or JSON per --output), dot or mermaid")
+	cmd.Flags().IntVar(&concurrency, "concurrency", registry.DefaultTreeConcurrency, "number of registry requests to run at once")
+	return cmd
+}
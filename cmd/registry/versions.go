@@ -11,7 +11,15 @@ import (
 )
 
 func newVersionsCmd(ecosystem string) *cobra.Command {
-	var minReleaseAge time.Duration
+	var (
+		minReleaseAge time.Duration
+		rawOutput     bool
+		field         string
+		all           bool
+		sortOrder     = registry.SortDate
+		versionRange  string
+		provenance    bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "versions <package>",
@@ -23,12 +31,6 @@ func newVersionsCmd(ecosystem string) *cobra.Command {
 				return err
 			}
 
-			options := registry.Options{MinReleaseAge: minReleaseAge}
-			output, err := client.Versions(context.Background(), args[0], options)
-			if err != nil {
-				return err
-			}
-
 			var f formatter.OutputFormatter
 			if shared.OutputFormat == shared.JSON {
 				f = formatter.NewJSONFormatter()
@@ -36,6 +38,23 @@ func newVersionsCmd(ecosystem string) *cobra.Command {
 				f = formatter.NewTableFormatter()
 			}
 
+			options := registry.Options{MinReleaseAge: minReleaseAge, All: all, Sort: sortOrder, Range: versionRange, Provenance: provenance}
+
+			if rawOutput || field != "" {
+				raw, err := client.RawVersions(context.Background(), args[0], options)
+				if err != nil {
+					return err
+				}
+				if rawOutput {
+					return formatter.NewJSONFormatter().Format(raw)
+				}
+				return f.Format(fieldValues(args[0], field, raw))
+			}
+
+			output, err := client.Versions(context.Background(), args[0], options)
+			if err != nil {
+				return err
+			}
 			return f.Format(output)
 		},
 	}
@@ -46,5 +65,32 @@ func newVersionsCmd(ecosystem string) *cobra.Command {
 		"",
 		"filter out versions released within this timespan (e.g. 7d, 6mo, 1y); ecosystems without release timestamps are unaffected",
 	)
+	cmd.Flags().StringVar(&versionRange, "range", "", "only list versions within this range, in the ecosystem's syntax (e.g. '^4.17', '>=4.2,<5') or as a vers: string")
+	cmd.Flags().Var(&sortOrder, "sort", "order versions by publish date or by the ecosystem's version ordering (date|version)")
+	cmd.Flags().BoolVar(&all, "all", false, "include yanked, deprecated and retracted versions with their status and reason")
+	cmd.Flags().BoolVar(&rawOutput, "raw", false, "Output the full registry data of every version, including registry-specific metadata")
+	cmd.Flags().StringVar(&field, "field", "", "print a single value of each version's registry data by dot-separated path (e.g. rust_version)")
+	cmd.MarkFlagsMutuallyExclusive("raw", "field")
+	if ecosystem == "npm" {
+		cmd.Flags().BoolVar(&provenance, "provenance", false, "add a column telling how each version was published: trusted publishing, provenance, signed, none or invalid signature")
+		cmd.MarkFlagsMutuallyExclusive("raw", "provenance")
+		cmd.MarkFlagsMutuallyExclusive("field", "provenance")
+	}
 	return cmd
 }
+
+// fieldValues picks field from the raw data of each version. Versions that
+// lack it are listed with no value.
+func fieldValues(name, field string, raw []map[string]any) *formatter.FieldValuesOutput {
+	output := &formatter.FieldValuesOutput{
+		Package: name,
+		Field:   field,
+		Values:  make([]formatter.FieldValueItem, 0, len(raw)),
+	}
+	for _, version := range raw {
+		number, _ := version["number"].(string)
+		value, _ := registry.Field(version, field)
+		output.Values = append(output.Values, formatter.FieldValueItem{Version: number, Value: value})
+	}
+	return output
+}
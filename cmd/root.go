@@ -4,8 +4,16 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/acidghost/a555pq/cmd/auth"
+	"github.com/acidghost/a555pq/cmd/bulk"
 	"github.com/acidghost/a555pq/cmd/container"
+	"github.com/acidghost/a555pq/cmd/cooldown"
+	"github.com/acidghost/a555pq/cmd/git"
+	"github.com/acidghost/a555pq/cmd/gitea"
 	"github.com/acidghost/a555pq/cmd/github"
+	"github.com/acidghost/a555pq/cmd/gitlab"
+	"github.com/acidghost/a555pq/cmd/outdated"
+	"github.com/acidghost/a555pq/cmd/purl"
 	"github.com/acidghost/a555pq/cmd/registry"
 	"github.com/acidghost/a555pq/cmd/shared"
 	"github.com/spf13/cobra"
@@ -39,8 +47,17 @@ func Execute() {
 func init() {
 	RootCmd.PersistentFlags().VarP(&shared.OutputFormat, "output", "o", "Output format (table|json)")
 
+	RootCmd.AddCommand(auth.Cmd)
+	RootCmd.AddCommand(bulk.Cmd)
 	RootCmd.AddCommand(container.Cmd)
+	RootCmd.AddCommand(cooldown.Cmd)
+	RootCmd.AddCommand(git.Cmd)
 	RootCmd.AddCommand(github.Cmd)
+	RootCmd.AddCommand(gitlab.Cmd)
+	RootCmd.AddCommand(gitea.Cmd)
+	RootCmd.AddCommand(gitea.CodebergCmd)
+	RootCmd.AddCommand(outdated.Cmd)
+	RootCmd.AddCommand(purl.Cmd)
 	registry.RegisterCommands(RootCmd)
 
 	RootCmd.AddCommand(versionCmd)
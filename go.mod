@@ -5,8 +5,11 @@ go 1.25.7
 toolchain go1.26.5
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/Masterminds/semver v1.5.0
+	github.com/git-pkgs/purl v0.1.15
 	github.com/git-pkgs/registries v0.6.4
+	github.com/git-pkgs/vers v0.3.0
 	github.com/google/go-containerregistry v0.21.7
 	github.com/spf13/cobra v1.10.2
 )
@@ -15,9 +18,7 @@ require (
 	github.com/docker/cli v29.5.3+incompatible // indirect
 	github.com/docker/docker-credential-helpers v0.9.6 // indirect
 	github.com/git-pkgs/pom v0.1.5 // indirect
-	github.com/git-pkgs/purl v0.1.15 // indirect
 	github.com/git-pkgs/spdx v0.1.4 // indirect
-	github.com/git-pkgs/vers v0.3.0 // indirect
 	github.com/github/go-spdx/v2 v2.7.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/klauspost/compress v1.18.6 // indirect
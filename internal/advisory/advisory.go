@@ -0,0 +1,190 @@
+package advisory
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/git-pkgs/vers"
+)
+
+// Affects reports whether version of the package is affected by the
+// advisory. Versions are compared with the rules of scheme, a vers scheme
+// such as "npm" or "pypi". Git commit ranges are ignored.
+func (v Vulnerability) Affects(ecosystem, name, version, scheme string) bool {
+	if v.Withdrawn != nil {
+		return false
+	}
+	for _, affected := range v.Affected {
+		if !affected.matches(ecosystem, name) {
+			continue
+		}
+		if slices.Contains(affected.Versions, version) {
+			return true
+		}
+		for _, r := range affected.Ranges {
+			if r.versioned() && r.contains(version, scheme) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r Range) versioned() bool {
+	return r.Type == "SEMVER" || r.Type == "ECOSYSTEM"
+}
+
+// sortedEvents returns the events ordered by version, as the OSV evaluation
+// algorithm requires. An introduced version of "0" sorts first.
+func (r Range) sortedEvents(scheme string) []Event {
+	events := slices.Clone(r.Events)
+	version := func(e Event) string {
+		switch {
+		case e.Introduced != "":
+			return e.Introduced
+		case e.Fixed != "":
+			return e.Fixed
+		case e.LastAffected != "":
+			return e.LastAffected
+		default:
+			return e.Limit
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		vi, vj := version(events[i]), version(events[j])
+		if vi == "0" || vj == "0" {
+			return vi == "0" && vj != "0"
+		}
+		return vers.CompareWithScheme(vi, vj, scheme) < 0
+	})
+	return events
+}
+
+func (r Range) contains(version, scheme string) bool {
+	affected := false
+	for _, e := range r.sortedEvents(scheme) {
+		switch {
+		case e.Introduced != "":
+			if e.Introduced == "0" || vers.CompareWithScheme(version, e.Introduced, scheme) >= 0 {
+				affected = true
+			}
+		case e.Fixed != "":
+			if vers.CompareWithScheme(version, e.Fixed, scheme) >= 0 {
+				affected = false
+			}
+		case e.LastAffected != "":
+			if vers.CompareWithScheme(version, e.LastAffected, scheme) > 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
+
+// AffectedRanges describes the affected versions of the package as
+// constraints such as ">=1.0.0, <1.2.3", one per interval. Advisories that
+// only enumerate versions are described by that list.
+func (v Vulnerability) AffectedRanges(ecosystem, name, scheme string) []string {
+	var ranges []string
+	for _, affected := range v.Affected {
+		if !affected.matches(ecosystem, name) {
+			continue
+		}
+		versioned := false
+		for _, r := range affected.Ranges {
+			if !r.versioned() {
+				continue
+			}
+			versioned = true
+			ranges = append(ranges, r.intervals(scheme)...)
+		}
+		if !versioned {
+			ranges = append(ranges, affected.Versions...)
+		}
+	}
+	return ranges
+}
+
+func (r Range) intervals(scheme string) []string {
+	var (
+		intervals []string
+		lower     string
+		open      bool
+	)
+	for _, e := range r.sortedEvents(scheme) {
+		switch {
+		case e.Introduced != "":
+			lower, open = e.Introduced, true
+		case e.Fixed != "" && open:
+			intervals = append(intervals, interval(lower, "<"+e.Fixed))
+			open = false
+		case e.LastAffected != "" && open:
+			intervals = append(intervals, interval(lower, "<="+e.LastAffected))
+			open = false
+		}
+	}
+	if open {
+		intervals = append(intervals, interval(lower, ""))
+	}
+	return intervals
+}
+
+func interval(lower, upper string) string {
+	var parts []string
+	if lower != "0" {
+		parts = append(parts, ">="+lower)
+	}
+	if upper != "" {
+		parts = append(parts, upper)
+	}
+	if len(parts) == 0 {
+		return "*"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FixedVersions lists the versions the advisory is fixed in for the package.
+func (v Vulnerability) FixedVersions(ecosystem, name string) []string {
+	var fixed []string
+	for _, affected := range v.Affected {
+		if !affected.matches(ecosystem, name) {
+			continue
+		}
+		for _, r := range affected.Ranges {
+			for _, e := range r.Events {
+				if r.versioned() && e.Fixed != "" && !slices.Contains(fixed, e.Fixed) {
+					fixed = append(fixed, e.Fixed)
+				}
+			}
+		}
+	}
+	return fixed
+}
+
+// CVSS returns the CVSS vector of the advisory and its base score, which is
+// only computed for CVSS 3.x. CVSS 3.x vectors are preferred for that reason.
+func (v Vulnerability) CVSS() (vector string, score float64, ok bool) {
+	for _, kind := range []string{"CVSS_V3", "CVSS_V4", "CVSS_V2"} {
+		for _, s := range v.Severity {
+			if s.Type != kind {
+				continue
+			}
+			score, ok := cvss3BaseScore(s.Score)
+			return s.Score, score, ok
+		}
+	}
+	return "", 0, false
+}
+
+// SeverityLabel returns the severity assigned by the database, e.g. "HIGH"
+// for GitHub advisories, falling back to the rating of the CVSS 3.x score.
+func (v Vulnerability) SeverityLabel() string {
+	if severity, ok := v.DatabaseSpecific["severity"].(string); ok && severity != "" {
+		return strings.ToUpper(severity)
+	}
+	if _, score, ok := v.CVSS(); ok {
+		return cvssRating(score)
+	}
+	return ""
+}
@@ -0,0 +1,189 @@
+package advisory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const ghsaJSON = `{
+	"id": "GHSA-xxxx-yyyy-zzzz",
+	"aliases": ["CVE-2024-0001"],
+	"summary": "Prototype pollution",
+	"severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}],
+	"affected": [{
+		"package": {"ecosystem": "npm", "name": "widgets"},
+		"ranges": [{"type": "SEMVER", "events": [
+			{"introduced": "2.0.0"}, {"fixed": "2.3.1"},
+			{"introduced": "0"}, {"fixed": "1.4.2"}
+		]}]
+	}],
+	"database_specific": {"severity": "CRITICAL"}
+}`
+
+func decode(t *testing.T, data string) Vulnerability {
+	t.Helper()
+	var v Vulnerability
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return v
+}
+
+func TestAffects(t *testing.T) {
+	vuln := decode(t, ghsaJSON)
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"1.0.0", true},
+		{"1.4.1", true},
+		{"1.4.2", false},
+		{"1.9.0", false},
+		{"2.0.0", true},
+		{"2.3.0", true},
+		{"2.3.1", false},
+		{"3.0.0", false},
+	}
+	for _, tt := range tests {
+		if got := vuln.Affects("npm", "widgets", tt.version, "npm"); got != tt.want {
+			t.Errorf("Affects(%s) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+	if vuln.Affects("npm", "gadgets", "1.0.0", "npm") {
+		t.Error("advisory must not affect another package")
+	}
+
+	if got, want := vuln.AffectedRanges("npm", "widgets", "npm"), []string{"<1.4.2", ">=2.0.0, <2.3.1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AffectedRanges() = %v, want %v", got, want)
+	}
+	if got, want := vuln.FixedVersions("npm", "widgets"), []string{"2.3.1", "1.4.2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FixedVersions() = %v, want %v", got, want)
+	}
+	if got := vuln.SeverityLabel(); got != "CRITICAL" {
+		t.Errorf("SeverityLabel() = %q, want CRITICAL", got)
+	}
+}
+
+func TestAffectsLastAffectedAndVersions(t *testing.T) {
+	vuln := decode(t, `{
+		"id": "PYSEC-2024-1",
+		"affected": [
+			{"package": {"ecosystem": "PyPI", "name": "Some_Package"},
+			 "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "1.0"}, {"last_affected": "1.2"}]}]},
+			{"package": {"ecosystem": "PyPI", "name": "some-package"}, "versions": ["0.9rc1"]}
+		]
+	}`)
+
+	for version, want := range map[string]bool{"0.9": false, "0.9rc1": true, "1.0": true, "1.2": true, "1.2.1": false} {
+		if got := vuln.Affects("PyPI", "some.package", version, "pypi"); got != want {
+			t.Errorf("Affects(%s) = %v, want %v", version, got, want)
+		}
+	}
+	if got, want := vuln.AffectedRanges("PyPI", "some-package", "pypi"), []string{">=1.0, <=1.2", "0.9rc1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AffectedRanges() = %v, want %v", got, want)
+	}
+}
+
+func TestCVSS3BaseScore(t *testing.T) {
+	tests := []struct {
+		vector string
+		want   float64
+		ok     bool
+	}{
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 9.8, true},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", 10.0, true},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", 6.1, true},
+		{"CVSS:3.0/AV:L/AC:H/PR:H/UI:R/S:U/C:L/I:N/A:N", 1.8, true},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", 0, true},
+		{"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N", 0, false},
+		{"CVSS:3.1/AV:X/AC:L", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := cvss3BaseScore(tt.vector)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("cvss3BaseScore(%s) = %v, %v, want %v, %v", tt.vector, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	vuln := Vulnerability{Severity: []Severity{{Type: "CVSS_V3", Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N"}}}
+	if got := vuln.SeverityLabel(); got != "MEDIUM" {
+		t.Errorf("SeverityLabel() = %q, want MEDIUM from the CVSS score", got)
+	}
+}
+
+func TestOSVSource(t *testing.T) {
+	var queries []osvQuery
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v1/query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var q osvQuery
+		if err := json.Unmarshal(body, &q); err != nil {
+			t.Errorf("bad query %s: %v", body, err)
+		}
+		queries = append(queries, q)
+		if q.PageToken == "" {
+			fmt.Fprintf(w, `{"vulns": [%s], "next_page_token": "p2"}`, ghsaJSON)
+			return
+		}
+		fmt.Fprint(w, `{"vulns": [{"id": "OSV-2"}]}`)
+	}))
+	defer srv.Close()
+
+	vulns, err := NewOSVSource(srv.URL+"/").Vulnerabilities(context.Background(), "npm", "widgets")
+	if err != nil {
+		t.Fatalf("Vulnerabilities() error = %v", err)
+	}
+	if len(vulns) != 2 || vulns[0].ID != "GHSA-xxxx-yyyy-zzzz" || vulns[1].ID != "OSV-2" {
+		t.Errorf("unexpected vulnerabilities: %+v", vulns)
+	}
+	if len(queries) != 2 || queries[0].Package != (osvPackage{Name: "widgets", Ecosystem: "npm"}) || queries[1].PageToken != "p2" {
+		t.Errorf("unexpected queries: %+v", queries)
+	}
+}
+
+func TestDirSource(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "npm"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"npm/GHSA-xxxx-yyyy-zzzz.json": ghsaJSON,
+		"npm/GHSA-other.json":          strings.ReplaceAll(ghsaJSON, `"widgets"`, `"gadgets"`),
+		"npm/README.txt":               "not an advisory",
+		"PyPI/PYSEC-1.json":            strings.ReplaceAll(ghsaJSON, `"npm"`, `"PyPI"`),
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	vulns, err := NewDirSource(dir).Vulnerabilities(context.Background(), "npm", "widgets")
+	if err != nil {
+		t.Fatalf("Vulnerabilities() error = %v", err)
+	}
+	if len(vulns) != 1 || vulns[0].ID != "GHSA-xxxx-yyyy-zzzz" {
+		t.Errorf("unexpected vulnerabilities: %+v", vulns)
+	}
+
+	if _, err := NewDirSource(filepath.Join(dir, "missing")).Vulnerabilities(context.Background(), "npm", "widgets"); err == nil {
+		t.Error("expected error for a missing directory")
+	}
+}
@@ -0,0 +1,249 @@
+// Package advisory looks up the security advisories affecting a package in
+// the OSV database, either through an OSV-compatible API or an offline
+// export of it.
+package advisory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultOSVURL is the base URL of the public OSV API.
+const DefaultOSVURL = "https://api.osv.dev"
+
+// Vulnerability is an advisory in the OSV schema, restricted to the fields
+// needed to tell which versions it affects.
+// See https://ossf.github.io/osv-schema/.
+type Vulnerability struct {
+	ID               string         `json:"id"`
+	Aliases          []string       `json:"aliases"`
+	Summary          string         `json:"summary"`
+	Published        time.Time      `json:"published"`
+	Modified         time.Time      `json:"modified"`
+	Withdrawn        *time.Time     `json:"withdrawn"`
+	Severity         []Severity     `json:"severity"`
+	Affected         []Affected     `json:"affected"`
+	DatabaseSpecific map[string]any `json:"database_specific"`
+}
+
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+type Affected struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+	Ranges   []Range  `json:"ranges"`
+	Versions []string `json:"versions"`
+}
+
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event is a single range event. Exactly one field is set.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// Source looks up the advisories of a package. Ecosystem is the OSV
+// ecosystem name, see [OSVEcosystem].
+type Source interface {
+	Vulnerabilities(ctx context.Context, ecosystem, name string) ([]Vulnerability, error)
+}
+
+// osvEcosystems maps registry ecosystem names to OSV ecosystem names.
+var osvEcosystems = map[string]string{
+	"cargo":    "crates.io",
+	"composer": "Packagist",
+	"cran":     "CRAN",
+	"gem":      "RubyGems",
+	"golang":   "Go",
+	"hackage":  "Hackage",
+	"hex":      "Hex",
+	"julia":    "Julia",
+	"maven":    "Maven",
+	"npm":      "npm",
+	"nuget":    "NuGet",
+	"pub":      "Pub",
+	"pypi":     "PyPI",
+}
+
+// OSVEcosystem returns the OSV name of a registry ecosystem, and whether OSV
+// covers it at all.
+func OSVEcosystem(ecosystem string) (string, bool) {
+	name, ok := osvEcosystems[ecosystem]
+	return name, ok
+}
+
+// OSVSource queries an OSV-compatible API.
+type OSVSource struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewOSVSource returns a source querying the OSV API at baseURL, or the
+// public API when baseURL is empty.
+func NewOSVSource(baseURL string) *OSVSource {
+	if baseURL == "" {
+		baseURL = DefaultOSVURL
+	}
+	return &OSVSource{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+type osvQuery struct {
+	Package   osvPackage `json:"package"`
+	PageToken string     `json:"page_token,omitempty"`
+}
+
+type osvPackage struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+type osvQueryResponse struct {
+	Vulns         []Vulnerability `json:"vulns"`
+	NextPageToken string          `json:"next_page_token"`
+}
+
+func (s *OSVSource) Vulnerabilities(ctx context.Context, ecosystem, name string) ([]Vulnerability, error) {
+	query := osvQuery{Package: osvPackage{Name: name, Ecosystem: ecosystem}}
+
+	var vulns []Vulnerability
+	for {
+		body, err := json.Marshal(query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", s.baseURL+"/v1/query", bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query advisories: %w", err)
+		}
+
+		var page osvQueryResponse
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("unexpected status code from advisory database: %d", resp.StatusCode)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		vulns = append(vulns, page.Vulns...)
+		if page.NextPageToken == "" {
+			return vulns, nil
+		}
+		query.PageToken = page.NextPageToken
+	}
+}
+
+// DirSource reads advisories from a local OSV export, i.e. a directory of
+// OSV JSON files such as the unpacked all.zip archives published per
+// ecosystem. When the directory has a subdirectory named after the
+// ecosystem, only that one is read.
+type DirSource struct {
+	dir string
+}
+
+func NewDirSource(dir string) *DirSource {
+	return &DirSource{dir: dir}
+}
+
+func (s *DirSource) Vulnerabilities(_ context.Context, ecosystem, name string) ([]Vulnerability, error) {
+	root := s.dir
+	if info, err := os.Stat(filepath.Join(root, ecosystem)); err == nil && info.IsDir() {
+		root = filepath.Join(root, ecosystem)
+	}
+
+	var vulns []Vulnerability
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var vuln Vulnerability
+		if err := json.Unmarshal(data, &vuln); err != nil {
+			// Exports may contain other JSON files; skip what is not OSV.
+			return nil
+		}
+		if vuln.affects(ecosystem, name) {
+			vulns = append(vulns, vuln)
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("advisory directory '%s' not found", s.dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read advisories: %w", err)
+	}
+	return vulns, nil
+}
+
+// affects reports whether the advisory has an affected entry for the
+// package, regardless of versions.
+func (v Vulnerability) affects(ecosystem, name string) bool {
+	for _, affected := range v.Affected {
+		if affected.matches(ecosystem, name) {
+			return true
+		}
+	}
+	return false
+}
+
+var pypiSeparators = regexp.MustCompile(`[-_.]+`)
+
+// matches compares package names the way the ecosystem does: PyPI names are
+// normalised as in PEP 503 and NuGet names are case-insensitive.
+func (a Affected) matches(ecosystem, name string) bool {
+	if a.Package.Ecosystem != ecosystem {
+		return false
+	}
+	switch ecosystem {
+	case "PyPI":
+		normalize := func(s string) string { return pypiSeparators.ReplaceAllString(strings.ToLower(s), "-") }
+		return normalize(a.Package.Name) == normalize(name)
+	case "NuGet":
+		return strings.EqualFold(a.Package.Name, name)
+	default:
+		return a.Package.Name == name
+	}
+}
@@ -0,0 +1,33 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/git-pkgs/purl"
+)
+
+// ImageFromPURL returns the image reference a pkg:docker package URL points
+// to, e.g. "ghcr.io/owner/app:1.0" for
+// pkg:docker/owner/app@1.0?repository_url=ghcr.io. Digests cannot be looked
+// up, so for a digest version the tag qualifier is used when present.
+func ImageFromPURL(p *purl.PURL) (string, error) {
+	if p.Type != "docker" {
+		return "", fmt.Errorf("not a docker package URL: %s", p)
+	}
+
+	image := p.FullName()
+	if registry := p.RepositoryURL(); registry != "" {
+		registry = strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+		image = strings.TrimSuffix(registry, "/") + "/" + image
+	}
+
+	tag := p.Version
+	if strings.Contains(tag, ":") {
+		tag = p.Qualifier("tag")
+	}
+	if tag != "" {
+		image += ":" + tag
+	}
+	return image, nil
+}
@@ -0,0 +1,68 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/git-pkgs/purl"
+)
+
+func TestImageFromPURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		purl    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Docker Hub image",
+			purl: "pkg:docker/library/nginx",
+			want: "library/nginx",
+		},
+		{
+			name: "image with tag",
+			purl: "pkg:docker/library/nginx@1.25",
+			want: "library/nginx:1.25",
+		},
+		{
+			name: "image in another registry",
+			purl: "pkg:docker/coder/code-server@4.20.0?repository_url=ghcr.io",
+			want: "ghcr.io/coder/code-server:4.20.0",
+		},
+		{
+			name: "registry URL with scheme",
+			purl: "pkg:docker/org/app?repository_url=https://quay.io/",
+			want: "quay.io/org/app",
+		},
+		{
+			name: "digest with tag qualifier",
+			purl: "pkg:docker/library/nginx@sha256%3Aabc123?tag=1.25",
+			want: "library/nginx:1.25",
+		},
+		{
+			name: "digest without tag",
+			purl: "pkg:docker/library/nginx@sha256%3Aabc123",
+			want: "library/nginx",
+		},
+		{
+			name:    "not a docker package URL",
+			purl:    "pkg:npm/express",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := purl.Parse(tt.purl)
+			if err != nil {
+				t.Fatalf("purl.Parse(%q) error = %v", tt.purl, err)
+			}
+			got, err := ImageFromPURL(p)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ImageFromPURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ImageFromPURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// DOTFormatter writes dependency trees as Graphviz DOT graphs.
+type DOTFormatter struct {
+	writer io.Writer
+}
+
+func NewDOTFormatter() *DOTFormatter {
+	return &DOTFormatter{writer: os.Stdout}
+}
+
+func (f *DOTFormatter) Format(data any) error {
+	tree, ok := data.(*TreeOutput)
+	if !ok {
+		return fmt.Errorf("unsupported output type for dot format")
+	}
+
+	g := newGraph(tree)
+	fmt.Fprintln(f.writer, "digraph dependencies {")
+	fmt.Fprintln(f.writer, "  node [shape=box];")
+	for _, n := range g.nodes {
+		fmt.Fprintf(f.writer, "  %s [label=%s];\n", n.id, dotQuote(n.label))
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(f.writer, "  %s -> %s", e.from, e.to)
+		if e.label != "" {
+			fmt.Fprintf(f.writer, " [label=%s]", dotQuote(e.label))
+		}
+		fmt.Fprintln(f.writer, ";")
+	}
+	fmt.Fprintln(f.writer, "}")
+	return nil
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// MermaidFormatter writes dependency trees as Mermaid flowcharts.
+type MermaidFormatter struct {
+	writer io.Writer
+}
+
+func NewMermaidFormatter() *MermaidFormatter {
+	return &MermaidFormatter{writer: os.Stdout}
+}
+
+func (f *MermaidFormatter) Format(data any) error {
+	tree, ok := data.(*TreeOutput)
+	if !ok {
+		return fmt.Errorf("unsupported output type for mermaid format")
+	}
+
+	g := newGraph(tree)
+	fmt.Fprintln(f.writer, "graph TD")
+	for _, n := range g.nodes {
+		fmt.Fprintf(f.writer, "  %s[%s]\n", n.id, mermaidQuote(n.label))
+	}
+	for _, e := range g.edges {
+		if e.label != "" {
+			fmt.Fprintf(f.writer, "  %s -->|%s| %s\n", e.from, mermaidQuote(e.label), e.to)
+		} else {
+			fmt.Fprintf(f.writer, "  %s --> %s\n", e.from, e.to)
+		}
+	}
+	return nil
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
+
+// graph is a dependency tree flattened into unique nodes and edges, in the
+// order they first appear.
+type graph struct {
+	nodes []graphNode
+	edges []graphEdge
+	ids   map[string]string
+	seen  map[graphEdge]bool
+}
+
+type graphNode struct {
+	id, label string
+}
+
+type graphEdge struct {
+	from, to, label string
+}
+
+func newGraph(tree *TreeOutput) *graph {
+	g := &graph{ids: make(map[string]string), seen: make(map[graphEdge]bool)}
+	g.add(tree.Root)
+	return g
+}
+
+// add records node and the edges to its dependencies, and returns its id.
+// Unresolved dependencies get a node of their own per requirement.
+func (g *graph) add(node TreeNode) string {
+	label := node.Name
+	if node.Version != "" {
+		label += "@" + node.Version
+	} else if node.Requirement != "" {
+		label += " " + node.Requirement + " (unresolved)"
+	}
+
+	id, ok := g.ids[label]
+	if !ok {
+		id = fmt.Sprintf("n%d", len(g.nodes))
+		g.ids[label] = id
+		g.nodes = append(g.nodes, graphNode{id: id, label: label})
+	}
+
+	for _, dep := range node.Dependencies {
+		edge := graphEdge{from: id, to: g.add(dep), label: dep.Scope}
+		if !g.seen[edge] {
+			g.seen[edge] = true
+			g.edges = append(g.edges, edge)
+		}
+	}
+	return id
+}
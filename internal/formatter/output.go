@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"text/tabwriter"
 )
 
@@ -13,6 +15,9 @@ type OutputFormatter interface {
 
 type TableFormatter struct {
 	writer *tabwriter.Writer
+	// wroteHeader records whether a streamed table already printed its
+	// header, so that later chunks only append rows.
+	wroteHeader bool
 }
 
 func NewTableFormatter() *TableFormatter {
@@ -29,8 +34,42 @@ func (f *TableFormatter) Format(data any) error {
 		return f.formatVersions(v)
 	case *LatestOutput:
 		return f.formatLatest(v)
+	case *AdvisoriesOutput:
+		return f.formatAdvisories(v)
+	case *TreeOutput:
+		return f.formatTree(v)
+	case *DepsDiffOutput:
+		return f.formatDepsDiff(v)
+	case *FieldOutput:
+		return f.formatField(v)
+	case *FieldValuesOutput:
+		return f.formatFieldValues(v)
+	case *LatestBatchOutput:
+		return f.formatLatestBatch(v)
+	case *ProvenanceOutput:
+		return f.formatProvenance(v)
+	case *DownloadOutput:
+		return f.formatDownload(v)
+	case *OutdatedOutput:
+		return f.formatOutdated(v)
+	case *CooldownOutput:
+		return f.formatCooldown(v)
 	case *BrowseOutput:
 		return f.formatBrowse(v)
+	case *ActionsOutput:
+		return f.formatActions(v)
+	case *ComponentsOutput:
+		return f.formatComponents(v)
+	case *OrgOutput:
+		return f.formatOrg(v)
+	case *PackagesOutput:
+		return f.formatPackages(v)
+	case *PackageShowOutput:
+		return f.formatPackageShow(v)
+	case *AuthStatusOutput:
+		return f.formatAuthStatus(v)
+	case *GitVersionsOutput:
+		return f.formatGitVersions(v)
 	case *ContainerShowOutput:
 		return f.formatContainerShow(v)
 	case *ContainerLatestOutput:
@@ -43,6 +82,12 @@ func (f *TableFormatter) Format(data any) error {
 func (f *TableFormatter) formatShow(data *ShowOutput) error {
 	fmt.Fprintf(f.writer, "Name:\t%s\n", data.Name)
 	fmt.Fprintf(f.writer, "Version:\t%s\n", data.Version)
+	if data.UploadDate != "" {
+		fmt.Fprintf(f.writer, "Upload Date:\t%s\n", data.UploadDate)
+	}
+	if data.Status != "" {
+		fmt.Fprintf(f.writer, "Status:\t%s\n", data.Status)
+	}
 	if data.Description != "" {
 		fmt.Fprintf(f.writer, "Description:\t%s\n", data.Description)
 	}
@@ -59,6 +104,9 @@ func (f *TableFormatter) formatShow(data *ShowOutput) error {
 	if data.HomePage != "" {
 		fmt.Fprintf(f.writer, "Home Page:\t%s\n", data.HomePage)
 	}
+	if data.Integrity != "" {
+		fmt.Fprintf(f.writer, "Integrity:\t%s\n", data.Integrity)
+	}
 	if len(data.Dependencies) > 0 {
 		fmt.Fprintf(f.writer, "Dependencies:\t")
 		for i, dep := range data.Dependencies {
@@ -73,10 +121,41 @@ func (f *TableFormatter) formatShow(data *ShowOutput) error {
 }
 
 func (f *TableFormatter) formatVersions(data *VersionsOutput) error {
-	fmt.Fprintln(f.writer, "Version\tUpload Date")
-	fmt.Fprintln(f.writer, "-------\t-----------")
+	// Verification and status columns are only shown by the sources that
+	// report them.
+	headers := []string{"Version", "Upload Date"}
+	verification := slices.ContainsFunc(data.Versions, func(v VersionItem) bool { return v.Verification != "" })
+	if verification {
+		headers = append(headers, "Verification")
+	}
+	status := slices.ContainsFunc(data.Versions, func(v VersionItem) bool { return v.Status != "" })
+	if status {
+		headers = append(headers, "Status", "Reason")
+	}
+	provenance := slices.ContainsFunc(data.Versions, func(v VersionItem) bool { return v.Provenance != "" })
+	if provenance {
+		headers = append(headers, "Provenance")
+	}
+
+	underlines := make([]string, len(headers))
+	for i, h := range headers {
+		underlines[i] = strings.Repeat("-", len(h))
+	}
+	fmt.Fprintln(f.writer, strings.Join(headers, "\t"))
+	fmt.Fprintln(f.writer, strings.Join(underlines, "\t"))
+
 	for _, v := range data.Versions {
-		fmt.Fprintf(f.writer, "%s\t%s\n", v.Version, v.UploadDate)
+		row := []string{v.Version, v.UploadDate}
+		if verification {
+			row = append(row, v.Verification)
+		}
+		if status {
+			row = append(row, v.Status, v.Reason)
+		}
+		if provenance {
+			row = append(row, v.Provenance)
+		}
+		fmt.Fprintln(f.writer, strings.Join(row, "\t"))
 	}
 	return f.writer.Flush()
 }
@@ -86,6 +165,307 @@ func (f *TableFormatter) formatLatest(data *LatestOutput) error {
 	return f.writer.Flush()
 }
 
+func (f *TableFormatter) formatAdvisories(data *AdvisoriesOutput) error {
+	fmt.Fprintf(f.writer, "ID\tAliases\tSeverity\tCVSS\tAffected\tPatched\tAffects %s\n", data.Version)
+	fmt.Fprintf(f.writer, "--\t-------\t--------\t----\t--------\t-------\t%s\n", strings.Repeat("-", len("Affects ")+len(data.Version)))
+	for _, a := range data.Advisories {
+		patched := strings.Join(a.Patched, ", ")
+		if patched == "" {
+			patched = "none"
+		}
+		affects := "no"
+		if a.AffectsVersion {
+			affects = "yes"
+		}
+		fmt.Fprintf(f.writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			a.ID, strings.Join(a.Aliases, ", "), a.Severity, a.CVSS, strings.Join(a.Affected, " || "), patched, affects)
+	}
+	return f.writer.Flush()
+}
+
+func (f *TableFormatter) formatTree(data *TreeOutput) error {
+	fmt.Fprintln(f.writer, treeLabel(data.Root))
+	f.formatTreeChildren(data.Root.Dependencies, "")
+	fmt.Fprintf(f.writer, "\n%d unique packages, %d levels deep\n", data.Summary.UniquePackages, data.Summary.MaxDepth)
+	return f.writer.Flush()
+}
+
+func (f *TableFormatter) formatTreeChildren(nodes []TreeNode, prefix string) {
+	for i, node := range nodes {
+		branch, indent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Fprintf(f.writer, "%s%s%s\n", prefix, branch, treeLabel(node))
+		f.formatTreeChildren(node.Dependencies, prefix+indent)
+	}
+}
+
+// treeLabel describes a tree node as "name@version (requirement)" with its
+// scope and markers.
+func treeLabel(node TreeNode) string {
+	label := node.Name
+	if node.Version != "" {
+		label += "@" + node.Version
+	}
+	var notes []string
+	if node.Requirement != "" {
+		notes = append(notes, node.Requirement)
+	}
+	if node.Scope != "" {
+		notes = append(notes, node.Scope)
+	}
+	if len(notes) > 0 {
+		label += " (" + strings.Join(notes, ", ") + ")"
+	}
+	switch {
+	case node.Cycle:
+		label += " [cycle]"
+	case node.Deduped:
+		label += " [deduped]"
+	}
+	if node.Error != "" {
+		label += " [error: " + node.Error + "]"
+	}
+	return label
+}
+
+func (f *TableFormatter) formatDepsDiff(data *DepsDiffOutput) error {
+	fmt.Fprintf(f.writer, "Package:\t%s\n", data.Package)
+	for _, v := range []struct{ label, version, date string }{{"From", data.From, data.FromDate}, {"To", data.To, data.ToDate}} {
+		fmt.Fprintf(f.writer, "%s:\t%s", v.label, v.version)
+		if v.date != "" {
+			fmt.Fprintf(f.writer, " (released %s)", v.date)
+		}
+		fmt.Fprintln(f.writer)
+	}
+	fmt.Fprintln(f.writer)
+
+	if len(data.Changes) == 0 {
+		fmt.Fprintln(f.writer, "No dependency changes")
+		return f.writer.Flush()
+	}
+
+	fmt.Fprintln(f.writer, "Scope\tChange\tDependency\tFrom\tTo")
+	fmt.Fprintln(f.writer, "-----\t------\t----------\t----\t--")
+	for _, c := range data.Changes {
+		from, to := requirementLabel(c.From, c.FromOptional), requirementLabel(c.To, c.ToOptional)
+		switch c.Change {
+		case "added":
+			from = "-"
+		case "removed":
+			to = "-"
+		}
+		fmt.Fprintf(f.writer, "%s\t%s\t%s\t%s\t%s\n", c.Scope, c.Change, c.Name, from, to)
+	}
+	return f.writer.Flush()
+}
+
+// requirementLabel shows an empty requirement as "*" and flags optional
+// dependencies.
+func requirementLabel(requirement string, optional bool) string {
+	if requirement == "" {
+		requirement = "*"
+	}
+	if optional {
+		requirement += " (optional)"
+	}
+	return requirement
+}
+
+func (f *TableFormatter) formatField(data *FieldOutput) error {
+	fmt.Fprintln(f.writer, fieldString(data.Value))
+	return f.writer.Flush()
+}
+
+func (f *TableFormatter) formatFieldValues(data *FieldValuesOutput) error {
+	fmt.Fprintf(f.writer, "Version\t%s\n", data.Field)
+	fmt.Fprintf(f.writer, "-------\t%s\n", strings.Repeat("-", len(data.Field)))
+	for _, v := range data.Values {
+		fmt.Fprintf(f.writer, "%s\t%s\n", v.Version, fieldString(v.Value))
+	}
+	return f.writer.Flush()
+}
+
+// fieldString prints strings as they are, missing values as nothing and
+// other values as compact JSON.
+func fieldString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func (f *TableFormatter) formatLatestBatch(data *LatestBatchOutput) error {
+	if !f.wroteHeader {
+		fmt.Fprintln(f.writer, "Package\tLatest Version")
+		fmt.Fprintln(f.writer, "-------\t--------------")
+		f.wroteHeader = true
+	}
+	for _, r := range data.Results {
+		if r.Error != "" {
+			fmt.Fprintf(f.writer, "%s\terror: %s\n", r.Package, r.Error)
+			continue
+		}
+		fmt.Fprintf(f.writer, "%s\t%s\n", r.Package, r.Version)
+	}
+	return f.writer.Flush()
+}
+
+func (f *TableFormatter) formatProvenance(data *ProvenanceOutput) error {
+	fmt.Fprintf(f.writer, "Package:\t%s\n", data.Package)
+	fmt.Fprintf(f.writer, "Version:\t%s\n", data.Version)
+	trusted := "no"
+	if data.TrustedPublisher != "" {
+		trusted = "yes (" + data.TrustedPublisher + ")"
+	}
+	fmt.Fprintf(f.writer, "Trusted Publishing:\t%s\n", trusted)
+
+	switch p := data.Provenance; {
+	case p == nil:
+		fmt.Fprintln(f.writer, "Provenance:\tnone")
+	case p.Error != "":
+		fmt.Fprintf(f.writer, "Provenance:\terror: %s\n", p.Error)
+	default:
+		fmt.Fprintf(f.writer, "Provenance:\t%s\n", p.PredicateType)
+		for _, field := range []struct{ label, value string }{
+			{"Repository", p.Repository},
+			{"Workflow", p.Workflow},
+			{"Ref", p.Ref},
+			{"Commit", p.Commit},
+			{"Builder", p.Builder},
+			{"Build", p.BuildURL},
+		} {
+			if field.value != "" {
+				fmt.Fprintf(f.writer, "  %s:\t%s\n", field.label, field.value)
+			}
+		}
+	}
+
+	if len(data.Signatures) == 0 {
+		fmt.Fprintln(f.writer, "Signatures:\tnone")
+	} else {
+		fmt.Fprintln(f.writer, "Signatures:")
+		for _, s := range data.Signatures {
+			fmt.Fprintf(f.writer, "  %s:\t%s\n", s.KeyID, s.Status)
+		}
+	}
+	return f.writer.Flush()
+}
+
+func (f *TableFormatter) formatDownload(data *DownloadOutput) error {
+	fmt.Fprintf(f.writer, "Package:\t%s\n", data.Package)
+	fmt.Fprintf(f.writer, "Version:\t%s\n", data.Version)
+	fmt.Fprintf(f.writer, "File:\t%s\n", data.File)
+	fmt.Fprintf(f.writer, "URL:\t%s\n", data.URL)
+	fmt.Fprintf(f.writer, "Integrity:\t%s\n", emptyDash(data.Integrity))
+	fmt.Fprintf(f.writer, "Computed:\t%s\n", data.Computed)
+	verified := "no"
+	switch {
+	case data.Verified:
+		verified = "yes"
+	case data.Weak:
+		verified = "no (weak " + strings.SplitN(data.Integrity, "-", 2)[0] + " hash)"
+	}
+	fmt.Fprintf(f.writer, "Verified:\t%s\n", verified)
+	return f.writer.Flush()
+}
+
+func (f *TableFormatter) formatOutdated(data *OutdatedOutput) error {
+	fmt.Fprintf(f.writer, "Files:\t%s\n", strings.Join(data.Files, ", "))
+	fmt.Fprintln(f.writer)
+	if len(data.Dependencies) == 0 {
+		fmt.Fprintln(f.writer, "No dependencies found")
+		return f.writer.Flush()
+	}
+
+	fmt.Fprintln(f.writer, "Ecosystem\tPackage\tCurrent\tWanted\tLatest")
+	fmt.Fprintln(f.writer, "---------\t-------\t-------\t------\t------")
+	for _, d := range data.Dependencies {
+		name := d.Name
+		if d.Scope != "" {
+			name += " (" + d.Scope + ")"
+		}
+		if d.Error != "" {
+			fmt.Fprintf(f.writer, "%s\t%s\t%s\terror: %s\t\n", d.Ecosystem, name, versionAgeLabel(d.Current, d.CurrentAge), d.Error)
+			continue
+		}
+		if d.Source != "" {
+			fmt.Fprintf(f.writer, "%s\t%s\t%s\tskipped: from %s\t\n", d.Ecosystem, name, versionAgeLabel(d.Current, d.CurrentAge), d.Source)
+			continue
+		}
+		fmt.Fprintf(f.writer, "%s\t%s\t%s\t%s\t%s\n", d.Ecosystem, name,
+			versionAgeLabel(d.Current, d.CurrentAge), versionAgeLabel(d.Wanted, d.WantedAge), versionAgeLabel(d.Latest, d.LatestAge))
+	}
+	return f.writer.Flush()
+}
+
+func (f *TableFormatter) formatCooldown(data *CooldownOutput) error {
+	fmt.Fprintf(f.writer, "Files:\t%s\n", strings.Join(data.Files, ", "))
+	fmt.Fprintf(f.writer, "Min Release Age:\t%s\n", data.MinReleaseAge)
+	fmt.Fprintf(f.writer, "Checked:\t%d package versions", data.Checked)
+	if data.Undated > 0 {
+		fmt.Fprintf(f.writer, " (%d without a publish time)", data.Undated)
+	}
+	fmt.Fprintln(f.writer)
+	for i, p := range data.Skipped {
+		label := ""
+		if i == 0 {
+			label = "Skipped:"
+		}
+		fmt.Fprintf(f.writer, "%s\t%s/%s@%s from %s (%s)\n", label, p.Ecosystem, p.Name, p.Version, p.Source, p.File)
+	}
+	fmt.Fprintln(f.writer)
+
+	if len(data.Findings) == 0 {
+		fmt.Fprintf(f.writer, "No package versions younger than %s\n", data.MinReleaseAge)
+		return f.writer.Flush()
+	}
+
+	fmt.Fprintln(f.writer, "File\tPackage\tVersion\tPublished\tAge\tStatus")
+	fmt.Fprintln(f.writer, "----\t-------\t-------\t---------\t---\t------")
+	for _, d := range data.Findings {
+		status := "too new"
+		switch {
+		case d.Error != "":
+			status = "error: " + d.Error
+		case d.Undated:
+			status = "no publish time"
+		}
+		if d.Allowed {
+			status = "allowed (" + status + ")"
+		}
+		fmt.Fprintf(f.writer, "%s\t%s\t%s\t%s\t%s\t%s\n", d.File, d.Ecosystem+"/"+d.Name, d.Version, emptyDash(d.Published), emptyDash(d.Age), status)
+	}
+	return f.writer.Flush()
+}
+
+func emptyDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// versionAgeLabel shows a version along with its age, e.g. "1.2.3 (2y)",
+// and an unknown version as "-".
+func versionAgeLabel(version, age string) string {
+	switch {
+	case version == "":
+		return "-"
+	case age == "":
+		return version
+	}
+	return version + " (" + age + ")"
+}
+
 func (f *TableFormatter) formatBrowse(data *BrowseOutput) error {
 	fmt.Fprintf(f.writer, "Opening:\t%s\n", data.URL)
 	return f.writer.Flush()
@@ -118,6 +498,126 @@ func (f *TableFormatter) formatContainerLatest(data *ContainerLatestOutput) erro
 	return f.writer.Flush()
 }
 
+func (f *TableFormatter) formatActions(data *ActionsOutput) error {
+	fmt.Fprintln(f.writer, "Location\tAction\tRef\tPinned\tResolves To\tLatest In Major")
+	fmt.Fprintln(f.writer, "--------\t------\t---\t------\t-----------\t---------------")
+	for _, a := range data.Actions {
+		if a.Error != "" {
+			fmt.Fprintf(f.writer, "%s\t%s\t%s\t\terror: %s\t\n", a.Location, a.Action, a.Ref, a.Error)
+			continue
+		}
+		pinned := "no"
+		if a.Pinned {
+			pinned = "yes"
+		}
+		resolves := strings.Join(a.ResolvesTo, ", ")
+		if resolves == "" {
+			resolves = a.SHA
+		}
+		fmt.Fprintf(f.writer, "%s\t%s\t%s\t%s\t%s\t%s\n", a.Location, a.Action, a.Ref, pinned, resolves, a.LatestInMajor)
+	}
+	return f.writer.Flush()
+}
+
+func (f *TableFormatter) formatComponents(data *ComponentsOutput) error {
+	fmt.Fprintln(f.writer, "Component\tLatest Version\tTag\tUpload Date")
+	fmt.Fprintln(f.writer, "---------\t--------------\t---\t-----------")
+	for _, c := range data.Components {
+		component := c.Component
+		if component == "" {
+			component = "(root)"
+		}
+		fmt.Fprintf(f.writer, "%s\t%s\t%s\t%s\n", component, c.Version, c.Tag, c.UploadDate)
+	}
+	return f.writer.Flush()
+}
+
+func (f *TableFormatter) formatOrg(data *OrgOutput) error {
+	fmt.Fprintln(f.writer, "Repository\tVisibility\tArchived\tLatest Release\tRelease Date\tDays Since Push\tDefault Branch")
+	fmt.Fprintln(f.writer, "----------\t----------\t--------\t--------------\t------------\t---------------\t--------------")
+	for _, r := range data.Repositories {
+		archived := "no"
+		if r.Archived {
+			archived = "yes"
+		}
+		days := ""
+		if r.DaysSincePush >= 0 {
+			days = fmt.Sprintf("%d", r.DaysSincePush)
+		}
+		fmt.Fprintf(f.writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Name, r.Visibility, archived, r.LatestRelease, r.ReleaseDate, days, r.DefaultBranch)
+	}
+	return f.writer.Flush()
+}
+
+func (f *TableFormatter) formatPackages(data *PackagesOutput) error {
+	fmt.Fprintln(f.writer, "Package\tType\tVisibility\tLatest Version\tTags\tCreated")
+	fmt.Fprintln(f.writer, "-------\t----\t----------\t--------------\t----\t-------")
+	for _, p := range data.Packages {
+		fmt.Fprintf(f.writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			p.Name, p.Type, p.Visibility, p.LatestVersion, strings.Join(p.Tags, ", "), p.Created)
+	}
+	return f.writer.Flush()
+}
+
+func (f *TableFormatter) formatPackageShow(data *PackageShowOutput) error {
+	fmt.Fprintf(f.writer, "Name:\t%s\n", data.Name)
+	fmt.Fprintf(f.writer, "Type:\t%s\n", data.Type)
+	fmt.Fprintf(f.writer, "Owner:\t%s\n", data.Owner)
+	fmt.Fprintf(f.writer, "Visibility:\t%s\n", data.Visibility)
+	if data.Repository != "" {
+		fmt.Fprintf(f.writer, "Repository:\t%s\n", data.Repository)
+	}
+	fmt.Fprintf(f.writer, "URL:\t%s\n", data.URL)
+	fmt.Fprintf(f.writer, "Versions:\t%d\n", data.VersionCount)
+	fmt.Fprintf(f.writer, "Created:\t%s\n", data.Created)
+	fmt.Fprintf(f.writer, "Updated:\t%s\n", data.Updated)
+	if err := f.writer.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(f.writer)
+	fmt.Fprintln(f.writer, "Version\tTags\tCreated")
+	fmt.Fprintln(f.writer, "-------\t----\t-------")
+	for _, v := range data.Versions {
+		fmt.Fprintf(f.writer, "%s\t%s\t%s\n", v.Version, strings.Join(v.Tags, ", "), v.Created)
+	}
+	return f.writer.Flush()
+}
+
+func (f *TableFormatter) formatAuthStatus(data *AuthStatusOutput) error {
+	fmt.Fprintf(f.writer, "Host:\t%s\n", data.Host)
+	if data.Source == "" {
+		fmt.Fprintf(f.writer, "Source:\tnone\n")
+	} else {
+		fmt.Fprintf(f.writer, "Source:\t%s\n", data.Source)
+		fmt.Fprintf(f.writer, "Token Type:\t%s\n", data.TokenType)
+	}
+	if data.Login != "" {
+		fmt.Fprintf(f.writer, "Login:\t%s\n", data.Login)
+	}
+	if len(data.Scopes) > 0 {
+		fmt.Fprintf(f.writer, "Scopes:\t%s\n", strings.Join(data.Scopes, ", "))
+	}
+	if data.Expiration != "" {
+		fmt.Fprintf(f.writer, "Expires:\t%s\n", data.Expiration)
+	}
+	fmt.Fprintln(f.writer, "Sources:")
+	for _, s := range data.Sources {
+		fmt.Fprintf(f.writer, "  %s\t%s\n", s.Source, s.Status)
+	}
+	return f.writer.Flush()
+}
+
+func (f *TableFormatter) formatGitVersions(data *GitVersionsOutput) error {
+	fmt.Fprintln(f.writer, "Tag\tCommit\tDate")
+	fmt.Fprintln(f.writer, "---\t------\t----")
+	for _, t := range data.Tags {
+		fmt.Fprintf(f.writer, "%s\t%s\t%s\n", t.Name, t.Commit, t.Date)
+	}
+	return f.writer.Flush()
+}
+
 type JSONFormatter struct{}
 
 func NewJSONFormatter() *JSONFormatter {
@@ -129,3 +629,26 @@ func (f *JSONFormatter) Format(data any) error {
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(data)
 }
+
+// JSONLinesFormatter writes newline-delimited JSON, one compact object per
+// line, so that results can be consumed while a batch is still running.
+// Batch outputs are unwrapped into one line per result.
+type JSONLinesFormatter struct {
+	encoder *json.Encoder
+}
+
+func NewJSONLinesFormatter() *JSONLinesFormatter {
+	return &JSONLinesFormatter{encoder: json.NewEncoder(os.Stdout)}
+}
+
+func (f *JSONLinesFormatter) Format(data any) error {
+	if v, ok := data.(*LatestBatchOutput); ok {
+		for _, r := range v.Results {
+			if err := f.encoder.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return f.encoder.Encode(data)
+}
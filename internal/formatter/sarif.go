@@ -0,0 +1,144 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// SARIFFormatter writes cooldown reports as SARIF 2.1.0 logs, the format
+// code scanning services ingest.
+type SARIFFormatter struct {
+	writer io.Writer
+}
+
+func NewSARIFFormatter() *SARIFFormatter {
+	return &SARIFFormatter{writer: os.Stdout}
+}
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolURI      = "https://github.com/acidghost/a555pq"
+
+	cooldownRule = "release-cooldown"
+	undatedRule  = "release-undated"
+	lookupRule   = "release-lookup-failed"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	FullDescription  sarifMessage `json:"fullDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification"`
+}
+
+func (f *SARIFFormatter) Format(data any) error {
+	report, ok := data.(*CooldownOutput)
+	if !ok {
+		return fmt.Errorf("unsupported output type for sarif format")
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "a555pq",
+			InformationURI: toolURI,
+			Rules: []sarifRule{
+				{
+					ID:               cooldownRule,
+					ShortDescription: sarifMessage{"Package version released too recently"},
+					FullDescription:  sarifMessage{"A locked package version was published more recently than the minimum release age."},
+				},
+				{
+					ID:               undatedRule,
+					ShortDescription: sarifMessage{"Package version has no publish time"},
+					FullDescription:  sarifMessage{"The registry of a locked package version does not tell when it was published, so its release age cannot be checked."},
+				},
+				{
+					ID:               lookupRule,
+					ShortDescription: sarifMessage{"Package version could not be looked up"},
+					FullDescription:  sarifMessage{"The publish time of a locked package version could not be looked up in its registry."},
+				},
+			},
+		}},
+		Results: make([]sarifResult, 0, len(report.Findings)),
+	}
+	for _, finding := range report.Findings {
+		result := sarifResult{
+			RuleID: cooldownRule,
+			Level:  "error",
+			Message: sarifMessage{fmt.Sprintf("%s %s@%s was published %s ago, less than the minimum release age of %s",
+				finding.Ecosystem, finding.Name, finding.Version, finding.Age, report.MinReleaseAge)},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(finding.File)},
+			}}},
+		}
+		switch {
+		case finding.Error != "":
+			result.RuleID = lookupRule
+			result.Message.Text = fmt.Sprintf("%s %s@%s could not be looked up: %s", finding.Ecosystem, finding.Name, finding.Version, finding.Error)
+		case finding.Undated:
+			result.RuleID = undatedRule
+			result.Message.Text = fmt.Sprintf("%s %s@%s has no publish time, its release age cannot be checked", finding.Ecosystem, finding.Name, finding.Version)
+		}
+		if finding.Allowed {
+			result.Suppressions = []sarifSuppression{{Kind: "external", Justification: "allowlisted"}}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	encoder := json.NewEncoder(f.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+}
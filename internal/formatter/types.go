@@ -9,33 +9,284 @@ type Author struct {
 type VersionItem struct {
 	Version    string
 	UploadDate string
+	// Verification is the signature status of the version, for sources that
+	// report one. It is empty otherwise.
+	Verification string `json:",omitempty"`
+	// Status is "yanked", "deprecated" or "retracted" for versions in bad
+	// standing, with the Reason the registry gives, if any.
+	Status string `json:",omitempty"`
+	Reason string `json:",omitempty"`
+	// Provenance sums up how an npm version was published, when asked for.
+	Provenance string `json:",omitempty"`
 }
 
 type ShowOutput struct {
 	Name         string
 	Version      string
+	UploadDate   string `json:",omitempty"`
 	Description  string
 	Author       string
 	AuthorEmail  string
 	License      string
 	HomePage     string
+	Integrity    string `json:",omitempty"`
+	Status       string `json:",omitempty"`
 	Dependencies []string
+	// Warnings tell that the reported version or the whole package is
+	// deprecated, yanked or retracted.
+	Warnings []string `json:",omitempty"`
+	// Replacement is the package the registry suggests instead of a
+	// deprecated one.
+	Replacement string `json:",omitempty"`
+	// PURL is the package URL the output was queried by, if any.
+	PURL string `json:",omitempty"`
 }
 
 type VersionsOutput struct {
 	Package  string
 	Versions []VersionItem
+	// PURL is the package URL the output was queried by, if any.
+	PURL string `json:",omitempty"`
+}
+
+// TreeOutput is the resolved transitive dependency tree of a package.
+type TreeOutput struct {
+	Root    TreeNode
+	Summary TreeSummary
+}
+
+type TreeNode struct {
+	Name        string
+	Version     string `json:",omitempty"`
+	Requirement string `json:",omitempty"`
+	Scope       string `json:",omitempty"`
+	// Deduped marks a package whose dependencies are listed where it first
+	// appears in the tree.
+	Deduped bool `json:",omitempty"`
+	// Cycle marks a package that depends on itself through its ancestors.
+	Cycle bool `json:",omitempty"`
+	// Error tells why the package could not be resolved or expanded.
+	Error        string     `json:",omitempty"`
+	Dependencies []TreeNode `json:",omitempty"`
+}
+
+type TreeSummary struct {
+	// UniquePackages counts the distinct package versions below the root.
+	UniquePackages int
+	MaxDepth       int
+}
+
+// DepsDiffOutput lists how the dependencies of a package changed between two
+// of its versions.
+type DepsDiffOutput struct {
+	Package  string
+	From     string
+	FromDate string `json:",omitempty"`
+	To       string
+	ToDate   string `json:",omitempty"`
+	Changes  []DepChangeItem
+}
+
+type DepChangeItem struct {
+	Scope string
+	Name  string
+	// Change is "added", "removed" or "changed".
+	Change       string
+	From         string `json:",omitempty"`
+	To           string `json:",omitempty"`
+	FromOptional bool   `json:",omitempty"`
+	ToOptional   bool   `json:",omitempty"`
+}
+
+// AdvisoriesOutput lists the advisories affecting a package, and whether
+// they affect Version.
+type AdvisoriesOutput struct {
+	Package    string
+	Version    string
+	Advisories []AdvisoryItem
+}
+
+type AdvisoryItem struct {
+	ID       string
+	Aliases  []string
+	Summary  string
+	Severity string
+	// CVSS is the base score, empty when only a vector is known.
+	CVSS       string
+	CVSSVector string
+	Affected   []string
+	Patched    []string
+	// AffectsVersion tells whether the advisory affects the version of
+	// AdvisoriesOutput.
+	AffectsVersion bool
+}
+
+// FieldOutput is a single value picked from raw registry data.
+type FieldOutput struct {
+	Package string
+	Field   string
+	Value   any
+}
+
+// FieldValuesOutput is a value picked from the raw data of each version.
+type FieldValuesOutput struct {
+	Package string
+	Field   string
+	Values  []FieldValueItem
+}
+
+type FieldValueItem struct {
+	Version string
+	Value   any
 }
 
 type LatestOutput struct {
 	Package string
 	Version string
+	// PURL is the package URL the output was queried by, if any.
+	PURL string `json:",omitempty"`
+}
+
+// LatestBatchOutput is a chunk of results from a multi-package latest query.
+// Chunks are formatted as they arrive, so a batch may produce several.
+type LatestBatchOutput struct {
+	Results []LatestBatchItem
+}
+
+type LatestBatchItem struct {
+	Package string
+	Version string `json:",omitempty"`
+	Error   string `json:",omitempty"`
+}
+
+// ProvenanceOutput tells how an npm version was published.
+type ProvenanceOutput struct {
+	Package string
+	Version string
+	// TrustedPublisher is the CI provider that published the version
+	// through trusted publishing, e.g. "github", empty otherwise.
+	TrustedPublisher string `json:",omitempty"`
+	// Provenance is the build provenance attested for the version, nil
+	// when it was published without.
+	Provenance *ProvenanceInfo `json:",omitempty"`
+	Signatures []SignatureItem
+}
+
+type ProvenanceInfo struct {
+	PredicateType string
+	Repository    string `json:",omitempty"`
+	Workflow      string `json:",omitempty"`
+	Ref           string `json:",omitempty"`
+	Commit        string `json:",omitempty"`
+	Builder       string `json:",omitempty"`
+	BuildURL      string `json:",omitempty"`
+	// Error tells why the provenance could not be read.
+	Error string `json:",omitempty"`
+}
+
+type SignatureItem struct {
+	KeyID string
+	// Status is "valid", "invalid", "expired key" or "unknown key".
+	Status string
+}
+
+// DownloadOutput tells where an artifact was downloaded to and how it was
+// verified.
+type DownloadOutput struct {
+	Package string
+	Version string
+	File    string
+	URL     string
+	// Integrity is the hash the registry publishes for the artifact, empty
+	// when it publishes none.
+	Integrity string `json:",omitempty"`
+	// Computed is the hash of the downloaded artifact, in the algorithm and
+	// encoding of Integrity.
+	Computed string
+	// Verified tells that Computed matches a strong Integrity. Weak marks
+	// a sha1 Integrity, which matches but does not count as verification.
+	Verified bool
+	Weak     bool `json:",omitempty"`
+}
+
+// OutdatedOutput compares the dependencies declared in project manifests and
+// lockfiles with the versions their registries offer.
+type OutdatedOutput struct {
+	// Files are the manifests and lockfiles the dependencies were read
+	// from.
+	Files        []string
+	Dependencies []OutdatedItem
+}
+
+type OutdatedItem struct {
+	Ecosystem string
+	Name      string
+	// Scope is empty for runtime dependencies.
+	Scope       string `json:",omitempty"`
+	Requirement string `json:",omitempty"`
+	// Current is the version in use, Wanted the highest version Requirement
+	// allows and Latest the highest version overall. Each age is how long
+	// ago the version was published, e.g. "3mo".
+	Current    string `json:",omitempty"`
+	CurrentAge string `json:",omitempty"`
+	Wanted     string `json:",omitempty"`
+	WantedAge  string `json:",omitempty"`
+	Latest     string `json:",omitempty"`
+	LatestAge  string `json:",omitempty"`
+	// Source is where a lockfile locks the dependency from when that is not
+	// the registry, in which case it is not looked up.
+	Source string `json:",omitempty"`
+	Error  string `json:",omitempty"`
+}
+
+// CooldownOutput reports the locked package versions published more
+// recently than a minimum release age.
+type CooldownOutput struct {
+	MinReleaseAge string
+	// Files are the lockfiles the package versions were read from.
+	Files []string
+	// Checked counts the locked package versions looked up, and Undated
+	// those whose registry does not tell when they were published.
+	Checked int
+	Undated int
+	// Skipped are the package versions locked from elsewhere than the
+	// registry, e.g. git repositories, which are not looked up.
+	Skipped []SkippedPackage `json:",omitempty"`
+	// Findings are the package versions younger than MinReleaseAge or
+	// without a publish time, allowed or not, and those that could not be
+	// looked up.
+	Findings []CooldownFinding
+}
+
+type CooldownFinding struct {
+	File      string
+	Ecosystem string
+	Name      string
+	Version   string
+	Published string `json:",omitempty"`
+	Age       string `json:",omitempty"`
+	// Undated marks a version whose registry does not tell when it was
+	// published.
+	Undated bool `json:",omitempty"`
+	// Allowed marks a finding the allowlist exempts.
+	Allowed bool   `json:",omitempty"`
+	Error   string `json:",omitempty"`
+}
+
+type SkippedPackage struct {
+	File      string
+	Ecosystem string
+	Name      string
+	Version   string
+	Source    string
 }
 
 type BrowseOutput struct {
 	Package string
 	URL     string
 	Opened  bool
+	// PURL is the package URL the output was queried by, if any.
+	PURL string `json:",omitempty"`
 }
 
 type ContainerShowOutput struct {
@@ -47,9 +298,124 @@ type ContainerShowOutput struct {
 	Digest       string
 	Registry     string
 	FullImageRef string
+	// PURL is the package URL the output was queried by, if any.
+	PURL string `json:",omitempty"`
 }
 
 type ContainerLatestOutput struct {
 	Image   string
 	Version string
+	// PURL is the package URL the output was queried by, if any.
+	PURL string `json:",omitempty"`
+}
+
+type ActionsOutput struct {
+	Actions []ActionItem
+}
+
+type ActionItem struct {
+	Location      string
+	Action        string
+	Ref           string
+	Pinned        bool
+	SHA           string
+	ResolvesTo    []string
+	LatestInMajor string
+	Error         string `json:",omitempty"`
+}
+
+// ComponentsOutput lists the components of a monorepo, as told apart by their
+// tags, with the latest version of each. The root component is named "".
+type ComponentsOutput struct {
+	Repository string
+	Components []ComponentItem
+}
+
+type ComponentItem struct {
+	Component  string
+	Version    string
+	Tag        string
+	UploadDate string
+}
+
+// OrgOutput is the release inventory of an organisation or user account.
+type OrgOutput struct {
+	Owner        string
+	Repositories []OrgRepoItem
+}
+
+type OrgRepoItem struct {
+	Name          string
+	Visibility    string
+	Archived      bool
+	LatestRelease string
+	ReleaseDate   string
+	// DaysSincePush is -1 for repositories that were never pushed to.
+	DaysSincePush int
+	DefaultBranch string
+}
+
+// PackagesOutput lists the GitHub Packages of an owner with the latest
+// version of each.
+type PackagesOutput struct {
+	Owner    string
+	Packages []PackageItem
+}
+
+type PackageItem struct {
+	Name          string
+	Type          string
+	Visibility    string
+	Repository    string
+	LatestVersion string
+	Tags          []string
+	Created       string
+}
+
+type PackageShowOutput struct {
+	Name         string
+	Type         string
+	Owner        string
+	Visibility   string
+	Repository   string
+	URL          string
+	VersionCount int
+	Created      string
+	Updated      string
+	Versions     []PackageVersionItem
+}
+
+type PackageVersionItem struct {
+	Version string
+	Tags    []string
+	Created string
+}
+
+// AuthStatusOutput describes the credential used for a host. It never holds
+// the secret itself.
+type AuthStatusOutput struct {
+	Host       string
+	Source     string
+	TokenType  string
+	Login      string   `json:",omitempty"`
+	Scopes     []string `json:",omitempty"`
+	Expiration string   `json:",omitempty"`
+	Sources    []AuthSourceItem
+}
+
+type AuthSourceItem struct {
+	Source string
+	Status string
+}
+
+type GitVersionsOutput struct {
+	Repository string
+	Tags       []GitTagItem
+}
+
+type GitTagItem struct {
+	Name      string
+	Commit    string
+	Annotated bool
+	Date      string
 }
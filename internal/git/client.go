@@ -0,0 +1,355 @@
+// Package git lists the tags of a remote repository over the git smart HTTP
+// protocol, without cloning it.
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+)
+
+const (
+	uploadPackService = "git-upload-pack"
+	tagPrefix         = "refs/tags/"
+	userAgent         = "git/a555pq"
+)
+
+type Client struct {
+	httpClient *http.Client
+}
+
+func NewClient() *Client {
+	return &Client{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Tag is a tag of a remote repository.
+type Tag struct {
+	Name string
+	// Object is the object the tag ref points at: a tag object for annotated
+	// tags, usually a commit for lightweight tags.
+	Object string
+	// Commit is the peeled object, i.e. the commit an annotated tag points at.
+	// It equals Object for lightweight tags.
+	Commit    string
+	Annotated bool
+	// Date is the tagger date of annotated tags, or the committer date of
+	// lightweight tags, when the server supports fetching them. It is zero
+	// otherwise.
+	Date time.Time
+}
+
+// advertisement is the result of the initial service discovery request.
+type advertisement struct {
+	version int
+	// capabilities maps capability names to their (possibly empty) values.
+	capabilities map[string]string
+	// refs holds the v0 ref advertisement. It is empty for protocol v2,
+	// where refs are listed with an explicit ls-refs command.
+	refs []ref
+}
+
+type ref struct {
+	name   string
+	oid    string
+	peeled string
+}
+
+// NormalizeURL turns a repository URL into the base URL smart HTTP requests
+// are made against: https is assumed when no scheme is given and trailing
+// slashes are removed.
+func NormalizeURL(repoURL string) string {
+	if !strings.Contains(repoURL, "://") {
+		repoURL = "https://" + repoURL
+	}
+	return strings.TrimRight(repoURL, "/")
+}
+
+// ListTags lists the tags of the repository at repoURL. Protocol v2 ls-refs
+// is used when the server supports it, falling back to the v0 ref
+// advertisement otherwise. With withDates, tag dates are fetched when the
+// server supports shallow, tree-less fetches; failures to do so leave the
+// dates unset.
+func (c *Client) ListTags(repoURL string, withDates bool) ([]Tag, error) {
+	repoURL = NormalizeURL(repoURL)
+
+	adv, err := c.discover(repoURL)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := adv.refs
+	if adv.version == 2 {
+		if _, ok := adv.capabilities["ls-refs"]; !ok {
+			return nil, fmt.Errorf("server does not support ls-refs")
+		}
+		refs, err = c.lsRefs(repoURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tags := tagsFromRefs(refs)
+	if withDates && adv.version == 2 && supportsDates(adv.capabilities) && len(tags) > 0 {
+		if dates, err := c.fetchDates(repoURL, tags); err == nil {
+			for i := range tags {
+				tags[i].Date = dates[tags[i].Object]
+			}
+		}
+	}
+
+	return tags, nil
+}
+
+// GetVersions lists the tags of a repository in descending semver order,
+// followed by tags that are not versions.
+func (c *Client) GetVersions(repoURL string) ([]Tag, error) {
+	tags, err := c.ListTags(repoURL, true)
+	if err != nil {
+		return nil, err
+	}
+	SortTags(tags)
+	return tags, nil
+}
+
+// GetLatestVersion returns the highest non-prerelease semver tag.
+func (c *Client) GetLatestVersion(repoURL string) (string, error) {
+	tags, err := c.ListTags(repoURL, false)
+	if err != nil {
+		return "", err
+	}
+	SortTags(tags)
+
+	for _, tag := range tags {
+		if v := parseSemver(tag.Name); v != nil && v.Prerelease() == "" {
+			return tag.Name, nil
+		}
+	}
+	return "", fmt.Errorf("no version tags found for repository '%s'", repoURL)
+}
+
+func (c *Client) discover(repoURL string) (*advertisement, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/info/refs?service=%s", repoURL, uploadPackService), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Git-Protocol", "version=2")
+	req.Header.Set("User-Agent", userAgent)
+
+	body, err := c.do(req, repoURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAdvertisement(body)
+}
+
+func parseAdvertisement(body []byte) (*advertisement, error) {
+	pkts := &pktReader{r: bytes.NewReader(body)}
+
+	line, err := pkts.nextLine()
+	if err != nil || line != "# service="+uploadPackService {
+		return nil, fmt.Errorf("server does not support the smart HTTP protocol")
+	}
+	if _, err := pkts.next(); !errors.Is(err, errFlush) {
+		return nil, fmt.Errorf("malformed service advertisement")
+	}
+
+	adv := &advertisement{capabilities: make(map[string]string)}
+
+	line, err = pkts.nextLine()
+	if errors.Is(err, errFlush) {
+		// An empty repository advertises nothing at all under v0.
+		return adv, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("malformed ref advertisement: %w", err)
+	}
+
+	if line == "version 2" {
+		adv.version = 2
+		for {
+			line, err := pkts.nextLine()
+			if errors.Is(err, errFlush) {
+				return adv, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("malformed capability advertisement: %w", err)
+			}
+			key, value, _ := strings.Cut(line, "=")
+			adv.capabilities[key] = value
+		}
+	}
+
+	// Protocol v0: the first ref carries the capabilities after a NUL.
+	first, caps, _ := strings.Cut(line, "\x00")
+	for _, capability := range strings.Fields(caps) {
+		key, value, _ := strings.Cut(capability, "=")
+		adv.capabilities[key] = value
+	}
+
+	lines := []string{first}
+	for {
+		line, err := pkts.nextLine()
+		if errors.Is(err, errFlush) || errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("malformed ref advertisement: %w", err)
+		}
+		lines = append(lines, line)
+	}
+
+	byName := make(map[string]int)
+	for _, line := range lines {
+		oid, name, ok := strings.Cut(line, " ")
+		if !ok || name == "capabilities^{}" {
+			continue
+		}
+		if base, peeled := strings.CutSuffix(name, "^{}"); peeled {
+			if i, ok := byName[base]; ok {
+				adv.refs[i].peeled = oid
+			}
+			continue
+		}
+		byName[name] = len(adv.refs)
+		adv.refs = append(adv.refs, ref{name: name, oid: oid})
+	}
+
+	return adv, nil
+}
+
+func (c *Client) lsRefs(repoURL string) ([]ref, error) {
+	var body strings.Builder
+	body.WriteString(pktLine("command=ls-refs\n"))
+	body.WriteString(pktLine("agent=" + userAgent + "\n"))
+	body.WriteString(delimPkt)
+	body.WriteString(pktLine("peel\n"))
+	body.WriteString(pktLine("ref-prefix " + tagPrefix + "\n"))
+	body.WriteString(flushPkt)
+
+	resp, err := c.uploadPack(repoURL, body.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []ref
+	pkts := &pktReader{r: bytes.NewReader(resp)}
+	for {
+		line, err := pkts.nextLine()
+		if errors.Is(err, errFlush) || errors.Is(err, io.EOF) {
+			return refs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("malformed ls-refs response: %w", err)
+		}
+		if msg, ok := strings.CutPrefix(line, "ERR "); ok {
+			return nil, fmt.Errorf("server error: %s", msg)
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		r := ref{oid: fields[0], name: fields[1]}
+		for _, attr := range fields[2:] {
+			if peeled, ok := strings.CutPrefix(attr, "peeled:"); ok {
+				r.peeled = peeled
+			}
+		}
+		refs = append(refs, r)
+	}
+}
+
+func (c *Client) uploadPack(repoURL, body string) ([]byte, error) {
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/%s", repoURL, uploadPackService), strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-git-upload-pack-request")
+	req.Header.Set("Accept", "application/x-git-upload-pack-result")
+	req.Header.Set("Git-Protocol", "version=2")
+	req.Header.Set("User-Agent", userAgent)
+
+	return c.do(req, repoURL)
+}
+
+func (c *Client) do(req *http.Request, repoURL string) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to contact repository: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("repository '%s' not found", repoURL)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("access to repository '%s' denied", repoURL)
+	default:
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return body, nil
+}
+
+func tagsFromRefs(refs []ref) []Tag {
+	var tags []Tag
+	for _, r := range refs {
+		name, ok := strings.CutPrefix(r.name, tagPrefix)
+		if !ok {
+			continue
+		}
+		tag := Tag{Name: name, Object: r.oid, Commit: r.oid}
+		if r.peeled != "" {
+			tag.Commit = r.peeled
+			tag.Annotated = true
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// SortTags orders tags by descending semver precedence. Tags that are not
+// versions sort after all versions, by name.
+func SortTags(tags []Tag) {
+	sort.SliceStable(tags, func(i, j int) bool {
+		va, vb := parseSemver(tags[i].Name), parseSemver(tags[j].Name)
+		switch {
+		case va != nil && vb != nil:
+			if va.Equal(vb) {
+				return tags[i].Name < tags[j].Name
+			}
+			return vb.LessThan(va)
+		case va != nil:
+			return true
+		case vb != nil:
+			return false
+		default:
+			return tags[i].Name < tags[j].Name
+		}
+	})
+}
+
+func parseSemver(tag string) *semver.Version {
+	v, err := semver.NewVersion(strings.TrimPrefix(tag, "v"))
+	if err != nil {
+		return nil
+	}
+	return v
+}
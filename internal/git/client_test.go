@@ -0,0 +1,218 @@
+package git
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1" //nolint:gosec
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	commitA = "1111111111111111111111111111111111111111"
+	commitB = "2222222222222222222222222222222222222222"
+	commitC = "3333333333333333333333333333333333333333"
+)
+
+// tagObject is an annotated tag for v1.0.0, created at 1700000000.
+var tagObject = []byte("object " + commitA + "\ntype commit\ntag v1.0.0\ntagger Jane Doe <jane@example.com> 1700000000 +0100\n\nv1.0.0\n")
+
+// commitObject is the commit lightweight tag v1.1.0 points at.
+var commitObject = []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\nauthor Jane Doe <jane@example.com> 1710000000 +0000\ncommitter Jane Doe <jane@example.com> 1710000500 +0000\n\nrelease\n")
+
+func TestListTagsV2(t *testing.T) {
+	tagOID := objectName("tag", tagObject)
+	commitOID := objectName("commit", commitObject)
+
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Git-Protocol") != "version=2" {
+			t.Errorf("missing Git-Protocol header on %s", r.URL.Path)
+		}
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/repo.git/info/refs":
+			w.Header().Set("Content-Type", "application/x-git-upload-pack-advertisement")
+			fmt.Fprint(w, pktLine("# service=git-upload-pack\n")+flushPkt+
+				pktLine("version 2\n")+pktLine("agent=git/2.45.0\n")+pktLine("ls-refs=unborn\n")+
+				pktLine("fetch=shallow wait-for-done filter\n")+flushPkt)
+		case r.Method == "POST" && r.URL.Path == "/repo.git/git-upload-pack":
+			body, _ := io.ReadAll(r.Body)
+			requests = append(requests, string(body))
+			switch {
+			case strings.Contains(string(body), "command=ls-refs"):
+				fmt.Fprint(w, pktLine(tagOID+" refs/tags/v1.0.0 peeled:"+commitA+"\n")+
+					pktLine(commitOID+" refs/tags/v1.1.0\n")+
+					pktLine(commitB+" refs/tags/v1.2.0-rc.1\n")+
+					pktLine(commitC+" refs/tags/nightly\n")+flushPkt)
+			case strings.Contains(string(body), "command=fetch"):
+				pack := buildPack(t, packObject{objTag, tagObject}, packObject{objCommit, commitObject})
+				fmt.Fprint(w, pktLine("shallow-info\n")+pktLine("shallow "+commitOID+"\n")+delimPkt+
+					pktLine("packfile\n")+pktLine("\x01"+string(pack[:10]))+pktLine("\x02progress\n")+
+					pktLine("\x01"+string(pack[10:]))+flushPkt)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := &Client{httpClient: srv.Client()}
+	tags, err := client.GetVersions(srv.URL + "/repo.git")
+	if err != nil {
+		t.Fatalf("GetVersions() error = %v", err)
+	}
+
+	want := []Tag{
+		{Name: "v1.2.0-rc.1", Object: commitB, Commit: commitB},
+		{Name: "v1.1.0", Object: commitOID, Commit: commitOID, Date: time.Unix(1710000500, 0).UTC()},
+		{Name: "v1.0.0", Object: tagOID, Commit: commitA, Annotated: true, Date: time.Unix(1700000000, 0).UTC()},
+		{Name: "nightly", Object: commitC, Commit: commitC},
+	}
+	if len(tags) != len(want) {
+		t.Fatalf("got %+v, want %+v", tags, want)
+	}
+	for i := range want {
+		if tags[i] != want[i] {
+			t.Errorf("tags[%d] = %+v, want %+v", i, tags[i], want[i])
+		}
+	}
+
+	if len(requests) != 2 || !strings.Contains(requests[0], "ref-prefix refs/tags/") || !strings.Contains(requests[0], "peel") {
+		t.Errorf("unexpected ls-refs request: %q", requests)
+	}
+
+	latest, err := client.GetLatestVersion(srv.URL + "/repo.git")
+	if err != nil || latest != "v1.1.0" {
+		t.Errorf("GetLatestVersion() = %q, %v, want v1.1.0", latest, err)
+	}
+}
+
+func TestListTagsV0(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repo/info/refs" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, pktLine("# service=git-upload-pack\n")+flushPkt+
+			pktLine(commitA+" HEAD\x00multi_ack side-band-64k symref=HEAD:refs/heads/main\n")+
+			pktLine(commitA+" refs/heads/main\n")+
+			pktLine(commitB+" refs/tags/2.0.0\n")+
+			pktLine(commitC+" refs/tags/2.0.0^{}\n")+
+			pktLine(commitA+" refs/tags/1.9.9\n")+flushPkt)
+	}))
+	defer srv.Close()
+
+	client := &Client{httpClient: srv.Client()}
+	tags, err := client.GetVersions(srv.URL + "/repo/")
+	if err != nil {
+		t.Fatalf("GetVersions() error = %v", err)
+	}
+
+	want := []Tag{
+		{Name: "2.0.0", Object: commitB, Commit: commitC, Annotated: true},
+		{Name: "1.9.9", Object: commitA, Commit: commitA},
+	}
+	if len(tags) != len(want) {
+		t.Fatalf("got %+v, want %+v", tags, want)
+	}
+	for i := range want {
+		if tags[i] != want[i] {
+			t.Errorf("tags[%d] = %+v, want %+v", i, tags[i], want[i])
+		}
+	}
+}
+
+func TestListTagsNotSmartHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, commitA+"\trefs/heads/main\n")
+	}))
+	defer srv.Close()
+
+	client := &Client{httpClient: srv.Client()}
+	if _, err := client.ListTags(srv.URL, false); err == nil {
+		t.Error("expected error for a dumb HTTP server")
+	}
+}
+
+type packObject struct {
+	kind int
+	data []byte
+}
+
+func buildPack(t *testing.T, objects ...packObject) []byte {
+	t.Helper()
+
+	var pack bytes.Buffer
+	pack.WriteString("PACK")
+	_ = binary.Write(&pack, binary.BigEndian, uint32(2))
+	_ = binary.Write(&pack, binary.BigEndian, uint32(len(objects)))
+
+	for _, obj := range objects {
+		size := len(obj.data)
+		b := byte(obj.kind<<4) | byte(size&0x0f)
+		size >>= 4
+		for size > 0 {
+			pack.WriteByte(b | 0x80)
+			b = byte(size & 0x7f)
+			size >>= 7
+		}
+		pack.WriteByte(b)
+
+		zw := zlib.NewWriter(&pack)
+		if _, err := zw.Write(obj.data); err != nil {
+			t.Fatal(err)
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sum := sha1.Sum(pack.Bytes()) //nolint:gosec
+	pack.Write(sum[:])
+	return pack.Bytes()
+}
+
+func TestPackDatesObjectSize(t *testing.T) {
+	pack := func(size uint64) []byte {
+		var buf bytes.Buffer
+		buf.WriteString("PACK")
+		_ = binary.Write(&buf, binary.BigEndian, uint32(2))
+		_ = binary.Write(&buf, binary.BigEndian, uint32(1))
+
+		b := byte(objTag<<4) | byte(size&0x0f)
+		size >>= 4
+		for size > 0 {
+			buf.WriteByte(b | 0x80)
+			b = byte(size & 0x7f)
+			size >>= 7
+		}
+		buf.WriteByte(b)
+
+		zw := zlib.NewWriter(&buf)
+		_, _ = zw.Write(tagObject)
+		_ = zw.Close()
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name string
+		size uint64
+	}{
+		{name: "larger than the limit", size: 1 << 40},
+		{name: "larger than the stream", size: uint64(len(tagObject)) + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := packDates(pack(tt.size)); err == nil {
+				t.Error("expected error for a wrong object size")
+			}
+		})
+	}
+}
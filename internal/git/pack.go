@@ -0,0 +1,265 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1" //nolint:gosec // git object names are SHA-1
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxObjectSize bounds the size a pack header may claim for a single object.
+// Only tags and commits are read, which are far smaller than this.
+const maxObjectSize = 16 << 20
+
+// Pack object types.
+const (
+	objCommit   = 1
+	objTag      = 4
+	objOfsDelta = 6
+	objRefDelta = 7
+)
+
+// supportsDates reports whether a v2 server can serve the shallow, tree-less
+// fetch used to read tag and commit dates.
+func supportsDates(capabilities map[string]string) bool {
+	fetch, ok := capabilities["fetch"]
+	if !ok {
+		return false
+	}
+	features := strings.Fields(fetch)
+	has := func(feature string) bool {
+		for _, f := range features {
+			if f == feature {
+				return true
+			}
+		}
+		return false
+	}
+	return has("shallow") && has("filter")
+}
+
+// fetchDates fetches the objects the tags point at, without trees or
+// history, and returns the tagger or committer date of each by object name.
+func (c *Client) fetchDates(repoURL string, tags []Tag) (map[string]time.Time, error) {
+	var body strings.Builder
+	body.WriteString(pktLine("command=fetch\n"))
+	body.WriteString(pktLine("agent=" + userAgent + "\n"))
+	body.WriteString(delimPkt)
+	body.WriteString(pktLine("no-progress\n"))
+	body.WriteString(pktLine("filter tree:0\n"))
+	body.WriteString(pktLine("deepen 1\n"))
+	seen := make(map[string]struct{})
+	for _, tag := range tags {
+		if _, ok := seen[tag.Object]; ok {
+			continue
+		}
+		seen[tag.Object] = struct{}{}
+		body.WriteString(pktLine("want " + tag.Object + "\n"))
+	}
+	body.WriteString(pktLine("done\n"))
+	body.WriteString(flushPkt)
+
+	resp, err := c.uploadPack(repoURL, body.String())
+	if err != nil {
+		return nil, err
+	}
+
+	pack, err := readPackfileSection(resp)
+	if err != nil {
+		return nil, err
+	}
+	return packDates(pack)
+}
+
+// readPackfileSection extracts the packfile from a v2 fetch response,
+// skipping the other sections and demultiplexing the side-band.
+func readPackfileSection(resp []byte) ([]byte, error) {
+	pkts := &pktReader{r: bytes.NewReader(resp)}
+
+	inPack := false
+	var pack bytes.Buffer
+	for {
+		payload, err := pkts.next()
+		switch {
+		case errors.Is(err, errDelim):
+			continue
+		case errors.Is(err, errFlush), errors.Is(err, io.EOF):
+			if !inPack {
+				return nil, fmt.Errorf("fetch response has no packfile")
+			}
+			return pack.Bytes(), nil
+		case err != nil:
+			return nil, fmt.Errorf("malformed fetch response: %w", err)
+		}
+
+		if !inPack {
+			line := strings.TrimSuffix(string(payload), "\n")
+			if msg, ok := strings.CutPrefix(line, "ERR "); ok {
+				return nil, fmt.Errorf("server error: %s", msg)
+			}
+			inPack = line == "packfile"
+			continue
+		}
+
+		if len(payload) == 0 {
+			continue
+		}
+		switch payload[0] {
+		case 1:
+			pack.Write(payload[1:])
+		case 3:
+			return nil, fmt.Errorf("server error: %s", strings.TrimSpace(string(payload[1:])))
+		}
+	}
+}
+
+// packDates walks a packfile and returns the tagger date of every tag object
+// and the committer date of every commit, keyed by object name. Deltified
+// objects are skipped: tags and shallow commits are stored whole in practice.
+func packDates(pack []byte) (map[string]time.Time, error) {
+	r := bytes.NewReader(pack)
+
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil || string(header[:4]) != "PACK" {
+		return nil, fmt.Errorf("invalid packfile header")
+	}
+	count := binary.BigEndian.Uint32(header[8:])
+
+	dates := make(map[string]time.Time)
+	for range count {
+		objType, size, err := readObjectHeader(r)
+		if err != nil {
+			return nil, err
+		}
+
+		switch objType {
+		case objOfsDelta:
+			if err := skipOffset(r); err != nil {
+				return nil, err
+			}
+		case objRefDelta:
+			if _, err := r.Seek(20, io.SeekCurrent); err != nil {
+				return nil, err
+			}
+		}
+
+		data, err := inflate(r, size)
+		if err != nil {
+			return nil, err
+		}
+
+		var kind, field string
+		switch objType {
+		case objCommit:
+			kind, field = "commit", "committer "
+		case objTag:
+			kind, field = "tag", "tagger "
+		default:
+			continue
+		}
+
+		if date, ok := signatureDate(data, field); ok {
+			dates[objectName(kind, data)] = date
+		}
+	}
+	return dates, nil
+}
+
+func readObjectHeader(r io.ByteReader) (int, uint64, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, 0, fmt.Errorf("truncated packfile: %w", err)
+	}
+	objType := int(b>>4) & 7
+	size := uint64(b & 0x0f)
+	for shift := uint(4); b&0x80 != 0; shift += 7 {
+		if b, err = r.ReadByte(); err != nil {
+			return 0, 0, fmt.Errorf("truncated packfile: %w", err)
+		}
+		size |= uint64(b&0x7f) << shift
+	}
+	return objType, size, nil
+}
+
+func skipOffset(r io.ByteReader) error {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return fmt.Errorf("truncated packfile: %w", err)
+		}
+		if b&0x80 == 0 {
+			return nil
+		}
+	}
+}
+
+func inflate(r *bytes.Reader, size uint64) ([]byte, error) {
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid packed object: %w", err)
+	}
+	defer zr.Close()
+
+	if size > maxObjectSize {
+		return nil, fmt.Errorf("packed object of %d bytes exceeds the %d byte limit", size, maxObjectSize)
+	}
+	data, err := io.ReadAll(io.LimitReader(zr, int64(size)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid packed object: %w", err)
+	}
+	if uint64(len(data)) != size {
+		return nil, fmt.Errorf("invalid packed object: %w", io.ErrUnexpectedEOF)
+	}
+	// Drain the stream so the reader is positioned after the checksum.
+	if _, err := io.Copy(io.Discard, zr); err != nil {
+		return nil, fmt.Errorf("invalid packed object: %w", err)
+	}
+	return data, nil
+}
+
+func objectName(kind string, data []byte) string {
+	h := sha1.New() //nolint:gosec
+	fmt.Fprintf(h, "%s %d\x00", kind, len(data))
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// signatureDate parses the timestamp of the header line starting with field,
+// e.g. "tagger Jane Doe <jane@example.com> 1700000000 +0100".
+func signatureDate(data []byte, field string) (time.Time, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// End of headers.
+			break
+		}
+		rest, ok := strings.CutPrefix(line, field)
+		if !ok {
+			continue
+		}
+
+		end := strings.LastIndex(rest, ">")
+		if end < 0 {
+			return time.Time{}, false
+		}
+		fields := strings.Fields(rest[end+1:])
+		if len(fields) == 0 {
+			return time.Time{}, false
+		}
+		seconds, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Unix(seconds, 0).UTC(), true
+	}
+	return time.Time{}, false
+}
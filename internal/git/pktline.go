@@ -0,0 +1,70 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Special pkt-line packets. Their length fields are below the 4 bytes of
+// the header itself, so they carry no payload.
+const (
+	flushPkt = "0000"
+	delimPkt = "0001"
+)
+
+var (
+	errFlush = errors.New("flush packet")
+	errDelim = errors.New("delimiter packet")
+)
+
+// pktLine encodes s as a single pkt-line.
+func pktLine(s string) string {
+	return fmt.Sprintf("%04x%s", len(s)+4, s)
+}
+
+// pktReader reads pkt-lines from a git protocol stream.
+type pktReader struct {
+	r io.Reader
+}
+
+// next returns the payload of the next packet, or errFlush / errDelim for
+// the special packets.
+func (p *pktReader) next() ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(p.r, header[:]); err != nil {
+		return nil, err
+	}
+
+	length, err := strconv.ParseUint(string(header[:]), 16, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pkt-line length %q", header[:])
+	}
+
+	switch length {
+	case 0:
+		return nil, errFlush
+	case 1:
+		return nil, errDelim
+	case 2, 3:
+		// response-end and other reserved packets
+		return nil, errFlush
+	}
+
+	payload := make([]byte, length-4)
+	if _, err := io.ReadFull(p.r, payload); err != nil {
+		return nil, fmt.Errorf("truncated pkt-line: %w", err)
+	}
+	return payload, nil
+}
+
+// nextLine is like next but strips the trailing newline of text packets.
+func (p *pktReader) nextLine() (string, error) {
+	payload, err := p.next()
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSuffix(payload, []byte("\n"))), nil
+}
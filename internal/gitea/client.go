@@ -0,0 +1,215 @@
+package gitea
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+)
+
+// DefaultHost is the instance queried when no host is configured. Codeberg
+// is the largest public Forgejo instance.
+const DefaultHost = "codeberg.org"
+
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	token      string
+}
+
+// forgeEnv pairs each token variable with the variable naming the instance
+// the token belongs to.
+var forgeEnv = []struct{ token, host string }{
+	{"GITEA_TOKEN", "GITEA_HOST"},
+	{"FORGEJO_TOKEN", "FORGEJO_HOST"},
+}
+
+// getGiteaToken returns the first of GITEA_TOKEN and FORGEJO_TOKEN meant for
+// host: the instance its GITEA_HOST or FORGEJO_HOST names, or codeberg.org
+// when that variable is unset.
+func getGiteaToken(host string) string {
+	for _, env := range forgeEnv {
+		tokenHost := os.Getenv(env.host)
+		if tokenHost == "" {
+			tokenHost = DefaultHost
+		}
+		if normalizeHost(tokenHost) != host {
+			continue
+		}
+		if token := os.Getenv(env.token); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// ResolveHost returns the host to query: host when given, otherwise
+// GITEA_HOST or FORGEJO_HOST, otherwise codeberg.org. A scheme and trailing
+// slash are stripped.
+func ResolveHost(host string) string {
+	for _, env := range forgeEnv {
+		if host == "" {
+			host = os.Getenv(env.host)
+		}
+	}
+	if host == "" {
+		host = DefaultHost
+	}
+	return normalizeHost(host)
+}
+
+func normalizeHost(host string) string {
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	return strings.TrimSuffix(host, "/")
+}
+
+func NewClient(host string) *Client {
+	host = ResolveHost(host)
+	return &Client{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		baseURL: fmt.Sprintf("https://%s/api/v1", host),
+		token:   getGiteaToken(host),
+	}
+}
+
+func (c *Client) GetPackageInfo(name string) (*Repository, error) {
+	if err := validateOwnerRepo(name); err != nil {
+		return nil, err
+	}
+
+	var repo Repository
+	if err := c.fetchJSON(fmt.Sprintf("%s/repos/%s", c.baseURL, name), &repo); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, fmt.Errorf("repository '%s' not found", name)
+		}
+		return nil, err
+	}
+	return &repo, nil
+}
+
+func (c *Client) GetVersions(name string) ([]formatter.VersionItem, error) {
+	if err := validateOwnerRepo(name); err != nil {
+		return nil, err
+	}
+
+	var (
+		releaseURL = fmt.Sprintf("%s/repos/%s/releases?limit=50", c.baseURL, name)
+		tagURL     = fmt.Sprintf("%s/repos/%s/tags?limit=50", c.baseURL, name)
+
+		releases []Release
+		tags     []Tag
+	)
+
+	if err := c.fetchJSON(releaseURL, &releases); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, fmt.Errorf("repository '%s' not found", name)
+		}
+		return nil, fmt.Errorf("failed to fetch releases: %w", err)
+	}
+
+	if err := c.fetchJSON(tagURL, &tags); err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	releaseTags := make(map[string]struct{})
+	versions := []formatter.VersionItem{}
+
+	for _, release := range releases {
+		releaseTags[release.TagName] = struct{}{}
+		if release.Draft || release.Prerelease {
+			continue
+		}
+		versions = append(versions, formatter.VersionItem{
+			Version:    release.TagName,
+			UploadDate: release.PublishedAt,
+		})
+	}
+
+	for _, tag := range tags {
+		if _, ok := releaseTags[tag.Name]; !ok {
+			versions = append(versions, formatter.VersionItem{
+				Version:    tag.Name,
+				UploadDate: tag.Commit.Created,
+			})
+		}
+	}
+
+	return versions, nil
+}
+
+func (c *Client) GetLatestVersion(name string) (string, error) {
+	if err := validateOwnerRepo(name); err != nil {
+		return "", err
+	}
+
+	var release Release
+	latestURL := fmt.Sprintf("%s/repos/%s/releases/latest", c.baseURL, name)
+	if err := c.fetchJSON(latestURL, &release); err == nil && release.TagName != "" && !release.Prerelease {
+		return release.TagName, nil
+	}
+
+	versions, err := c.GetVersions(name)
+	if err != nil {
+		return "", err
+	}
+
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no versions found for repository '%s'", name)
+	}
+
+	return versions[0].Version, nil
+}
+
+func validateOwnerRepo(name string) error {
+	parts := strings.Split(name, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid repository format: expected 'owner/repo', got '%s'", name)
+	}
+	return nil
+}
+
+// errNotFound is returned by fetchJSON when the API responds with 404.
+var errNotFound = errors.New("not found")
+
+func (c *Client) fetchJSON(url string, v any) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("token %s", c.token))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return errNotFound
+	case http.StatusUnauthorized:
+		return fmt.Errorf("authentication failed, check GITEA_TOKEN or FORGEJO_TOKEN")
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		return fmt.Errorf("access denied or rate limit exceeded, consider setting GITEA_TOKEN or FORGEJO_TOKEN")
+	default:
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
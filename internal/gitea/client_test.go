@@ -0,0 +1,93 @@
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetVersions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/repos/owner/repo/releases":
+			fmt.Fprint(w, `[
+				{"tag_name": "v2.0.0-rc1", "prerelease": true, "published_at": "2024-03-01T00:00:00Z"},
+				{"tag_name": "v1.0.0", "published_at": "2024-02-01T00:00:00Z"}
+			]`)
+		case "/api/v1/repos/owner/repo/tags":
+			fmt.Fprint(w, `[
+				{"name": "v2.0.0-rc1", "commit": {"created": "2024-02-28T00:00:00Z"}},
+				{"name": "v1.0.0", "commit": {"created": "2024-01-31T00:00:00Z"}},
+				{"name": "v0.9.0", "commit": {"created": "2023-12-01T00:00:00Z"}}
+			]`)
+		case "/api/v1/repos/owner/repo/releases/latest":
+			fmt.Fprint(w, `{"tag_name": "v1.0.0"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := &Client{httpClient: srv.Client(), baseURL: srv.URL + "/api/v1", token: "secret"}
+
+	versions, err := client.GetVersions("owner/repo")
+	if err != nil {
+		t.Fatalf("GetVersions() error = %v", err)
+	}
+	want := []string{"v1.0.0", "v0.9.0"}
+	if len(versions) != len(want) {
+		t.Fatalf("got %+v, want %v", versions, want)
+	}
+	for i, v := range versions {
+		if v.Version != want[i] {
+			t.Errorf("versions[%d] = %s, want %s", i, v.Version, want[i])
+		}
+	}
+
+	latest, err := client.GetLatestVersion("owner/repo")
+	if err != nil || latest != "v1.0.0" {
+		t.Errorf("GetLatestVersion() = %q, %v", latest, err)
+	}
+
+	if _, err := client.GetPackageInfo("owner/missing"); err == nil || err.Error() != "repository 'owner/missing' not found" {
+		t.Errorf("missing repository error = %v", err)
+	}
+	if _, err := client.GetVersions("invalid"); err == nil {
+		t.Error("expected error for malformed repository name")
+	}
+}
+
+func TestGetGiteaToken(t *testing.T) {
+	t.Setenv("GITEA_TOKEN", "gitea-secret")
+	t.Setenv("FORGEJO_TOKEN", "forgejo-secret")
+	t.Setenv("GITEA_HOST", "https://gitea.example.com/")
+	t.Setenv("FORGEJO_HOST", "")
+
+	tests := map[string]string{
+		"gitea.example.com": "gitea-secret",
+		DefaultHost:         "forgejo-secret",
+		"other.example.com": "",
+	}
+	for host, want := range tests {
+		if got := getGiteaToken(host); got != want {
+			t.Errorf("getGiteaToken(%q) = %q, want %q", host, got, want)
+		}
+	}
+
+	if got := ResolveHost(""); got != "gitea.example.com" {
+		t.Errorf("ResolveHost with GITEA_HOST = %q", got)
+	}
+	t.Setenv("GITEA_HOST", "")
+	t.Setenv("FORGEJO_HOST", "forgejo.example.com")
+	if got := ResolveHost(""); got != "forgejo.example.com" {
+		t.Errorf("ResolveHost with FORGEJO_HOST = %q", got)
+	}
+	if got := ResolveHost(DefaultHost); got != DefaultHost {
+		t.Errorf("ResolveHost(%q) = %q", DefaultHost, got)
+	}
+}
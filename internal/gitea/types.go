@@ -0,0 +1,62 @@
+package gitea
+
+import "time"
+
+type Repository struct {
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	FullName      string    `json:"full_name"`
+	Owner         User      `json:"owner"`
+	Description   string    `json:"description"`
+	Website       string    `json:"website"`
+	HTMLURL       string    `json:"html_url"`
+	CloneURL      string    `json:"clone_url"`
+	DefaultBranch string    `json:"default_branch"`
+	Private       bool      `json:"private"`
+	Fork          bool      `json:"fork"`
+	Mirror        bool      `json:"mirror"`
+	Archived      bool      `json:"archived"`
+	Language      string    `json:"language"`
+	Licenses      []string  `json:"licenses"`
+	Topics        []string  `json:"topics"`
+	StarsCount    int       `json:"stars_count"`
+	ForksCount    int       `json:"forks_count"`
+	OpenIssues    int       `json:"open_issues_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type User struct {
+	ID       int64  `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+	HTMLURL  string `json:"html_url"`
+}
+
+type Release struct {
+	ID          int64  `json:"id"`
+	TagName     string `json:"tag_name"`
+	Target      string `json:"target_commitish"`
+	Name        string `json:"name"`
+	Body        string `json:"body"`
+	Draft       bool   `json:"draft"`
+	Prerelease  bool   `json:"prerelease"`
+	CreatedAt   string `json:"created_at"`
+	PublishedAt string `json:"published_at"`
+	HTMLURL     string `json:"html_url"`
+	Author      User   `json:"author"`
+}
+
+type Tag struct {
+	Name    string    `json:"name"`
+	Message string    `json:"message"`
+	ID      string    `json:"id"`
+	Commit  TagCommit `json:"commit"`
+}
+
+type TagCommit struct {
+	SHA     string `json:"sha"`
+	URL     string `json:"url"`
+	Created string `json:"created"`
+}
@@ -0,0 +1,425 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+)
+
+// ActionRef is a single "uses: owner/repo[/path]@ref" reference found in a
+// workflow or composite action file.
+type ActionRef struct {
+	File string
+	Line int
+	// Repository is the owner/repo hosting the action.
+	Repository string
+	// Path is the sub-directory or reusable workflow path within the
+	// repository, without a leading slash.
+	Path string
+	Ref  string
+	// Comment is the trailing comment of the line, conventionally the version
+	// a pinned SHA corresponds to.
+	Comment string
+}
+
+// Action returns the reference without its ref, as written before the "@".
+func (r ActionRef) Action() string {
+	if r.Path == "" {
+		return r.Repository
+	}
+	return r.Repository + "/" + r.Path
+}
+
+var (
+	usesLine = regexp.MustCompile(`^(\s*(?:-\s+)?uses:\s*)(['"]?)([^\s'"#]+)(['"]?)(?:\s*#\s*(.*))?\s*$`)
+	fullSHA  = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// FindWorkflowFiles returns the YAML files at root, which may be a single
+// file or a directory searched recursively (e.g. ".github", to cover both
+// workflows and composite actions).
+func FindWorkflowFiles(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if ext := filepath.Ext(path); path == root || ext == ".yml" || ext == ".yaml" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ParseActionRefs extracts the remote action references from a workflow or
+// composite action file. Local ("./...") and Docker ("docker://...")
+// references are skipped.
+func ParseActionRefs(file string, data []byte) []ActionRef {
+	var refs []ActionRef
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		m := usesLine.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		if ref, ok := parseUses(m[3]); ok {
+			ref.File = file
+			ref.Line = line
+			ref.Comment = strings.TrimSpace(m[5])
+			refs = append(refs, ref)
+		}
+	}
+
+	return refs
+}
+
+func parseUses(value string) (ActionRef, bool) {
+	if strings.HasPrefix(value, "./") || strings.HasPrefix(value, "docker://") {
+		return ActionRef{}, false
+	}
+
+	at := strings.LastIndex(value, "@")
+	if at <= 0 || at == len(value)-1 {
+		return ActionRef{}, false
+	}
+
+	parts := strings.SplitN(value[:at], "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return ActionRef{}, false
+	}
+
+	ref := ActionRef{Repository: parts[0] + "/" + parts[1], Ref: value[at+1:]}
+	if len(parts) == 3 {
+		ref.Path = parts[2]
+	}
+	return ref, true
+}
+
+// IsFullSHA reports whether ref is a full 40 character commit SHA.
+func IsFullSHA(ref string) bool {
+	return fullSHA.MatchString(ref)
+}
+
+// ActionAudit is the result of resolving one [ActionRef].
+type ActionAudit struct {
+	ActionRef
+	// Pinned is true when the ref is a full commit SHA.
+	Pinned bool
+	// SHA is the commit the ref resolves to.
+	SHA string
+	// Matches lists the tags and branches pointing at SHA, most specific
+	// version tag first.
+	Matches []string
+	// Latest is the newest release tag sharing the major version of the ref,
+	// honouring the release-age cooldown, and LatestSHA its commit.
+	Latest    string
+	LatestSHA string
+	// PinTo and PinComment are what the reference should be rewritten to:
+	// the ref as an exact version, or the newest release of its major version
+	// for floating refs such as "v4" or "main".
+	PinTo      string
+	PinComment string
+	Err        error
+}
+
+type repoRefs struct {
+	versions []versionTag
+	bySHA    map[string][]string
+	err      error
+}
+
+type versionTag struct {
+	name    string
+	version *semver.Version
+	date    string
+}
+
+// AuditActions resolves every reference in refs. Lookups are shared between
+// references to the same repository; failures are reported per reference.
+// Releases published within minReleaseAge are not considered for Latest, nor
+// are tags without a date unless allowUndated is set.
+func (c *Client) AuditActions(refs []ActionRef, minReleaseAge time.Duration, allowUndated bool) []ActionAudit {
+	cache := make(map[string]*repoRefs)
+	shaCache := make(map[string]string)
+	cutoff := time.Now().Add(-minReleaseAge)
+
+	resolve := func(repo, ref string) (string, error) {
+		key := repo + "@" + ref
+		if sha, ok := shaCache[key]; ok {
+			return sha, nil
+		}
+		sha, err := c.ResolveRef(repo, ref)
+		if err == nil {
+			shaCache[key] = sha
+		}
+		return sha, err
+	}
+
+	audits := make([]ActionAudit, len(refs))
+	for i, ref := range refs {
+		audit := &audits[i]
+		audit.ActionRef = ref
+		audit.Pinned = IsFullSHA(ref.Ref)
+
+		info, ok := cache[ref.Repository]
+		if !ok {
+			info = c.loadRepoRefs(ref.Repository)
+			cache[ref.Repository] = info
+		}
+		if info.err != nil {
+			audit.Err = info.err
+			continue
+		}
+
+		if audit.Pinned {
+			audit.SHA = ref.Ref
+		} else {
+			sha, err := resolve(ref.Repository, ref.Ref)
+			if err != nil {
+				audit.Err = err
+				continue
+			}
+			audit.SHA = sha
+		}
+		audit.Matches = info.bySHA[audit.SHA]
+
+		if current := currentVersion(*audit); current != nil {
+			if latest := latestInMajor(info.versions, current.Major(), minReleaseAge, allowUndated, cutoff); latest != nil {
+				sha, err := resolve(ref.Repository, latest.name)
+				if err != nil {
+					audit.Err = err
+					continue
+				}
+				audit.Latest, audit.LatestSHA = latest.name, sha
+			}
+		}
+
+		audit.PinTo, audit.PinComment = audit.SHA, versionComment(*audit)
+		if !audit.Pinned && !isExactVersion(ref.Ref) && audit.LatestSHA != "" {
+			audit.PinTo, audit.PinComment = audit.LatestSHA, audit.Latest
+		}
+	}
+
+	return audits
+}
+
+// currentVersion determines the version a reference stands for: the ref
+// itself, a version tag pointing at the pinned SHA, or the version recorded
+// in the trailing comment.
+func currentVersion(audit ActionAudit) *semver.Version {
+	candidates := []string{audit.Ref}
+	candidates = append(candidates, audit.Matches...)
+	candidates = append(candidates, strings.Fields(audit.Comment)...)
+	for _, candidate := range candidates {
+		if v := parseVersionTag(candidate); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// versionComment picks the most specific version tag describing the audited
+// commit, falling back to the ref itself.
+func versionComment(audit ActionAudit) string {
+	for _, match := range audit.Matches {
+		if isExactVersion(match) {
+			return match
+		}
+	}
+	if len(audit.Matches) > 0 {
+		return audit.Matches[0]
+	}
+	if !audit.Pinned {
+		return audit.Ref
+	}
+	return audit.Comment
+}
+
+// latestInMajor returns the highest full release of major. With a cooldown,
+// releases published after cutoff are skipped, and so are releases whose date
+// is unknown unless allowUndated is set.
+func latestInMajor(versions []versionTag, major int64, minReleaseAge time.Duration, allowUndated bool, cutoff time.Time) *versionTag {
+	var latest *versionTag
+	for i, v := range versions {
+		if v.version.Major() != major || v.version.Prerelease() != "" || !isExactVersion(v.name) {
+			continue
+		}
+		if minReleaseAge > 0 {
+			published, err := time.Parse(time.RFC3339, v.date)
+			if err != nil && !allowUndated {
+				continue
+			}
+			if err == nil && published.After(cutoff) {
+				continue
+			}
+		}
+		if latest == nil || v.version.GreaterThan(latest.version) {
+			latest = &versions[i]
+		}
+	}
+	return latest
+}
+
+func parseVersionTag(tag string) *semver.Version {
+	if !strings.HasPrefix(tag, "v") && (tag == "" || tag[0] < '0' || tag[0] > '9') {
+		return nil
+	}
+	v, err := semver.NewVersion(tag)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// isExactVersion reports whether tag is a full major.minor.patch version
+// rather than a floating major or minor tag such as "v4" or "v4.1".
+func isExactVersion(tag string) bool {
+	return parseVersionTag(tag) != nil && strings.Count(strings.SplitN(tag, "-", 2)[0], ".") == 2
+}
+
+func (c *Client) loadRepoRefs(repo string) *repoRefs {
+	info := &repoRefs{bySHA: make(map[string][]string)}
+
+	versions, err := c.GetVersions(repo)
+	if err != nil {
+		info.err = err
+		return info
+	}
+	for _, item := range versions {
+		if v := parseVersionTag(item.Version); v != nil {
+			info.versions = append(info.versions, versionTag{name: item.Version, version: v, date: item.UploadDate})
+		}
+	}
+
+	tags, err := c.getTags(repo)
+	if err != nil {
+		info.err = err
+		return info
+	}
+	for _, tag := range tags {
+		info.bySHA[tag.Commit.SHA] = append(info.bySHA[tag.Commit.SHA], tag.Name)
+	}
+	// Prefer exact versions, then longer (more specific) names.
+	for _, names := range info.bySHA {
+		sort.SliceStable(names, func(i, j int) bool {
+			ei, ej := isExactVersion(names[i]), isExactVersion(names[j])
+			if ei != ej {
+				return ei
+			}
+			return len(names[i]) > len(names[j])
+		})
+	}
+
+	var heads []GitRef
+	url := fmt.Sprintf("%s/repos/%s/git/matching-refs/heads", githubAPIURL, repo)
+	if err := c.fetchJSON(url, &heads); err != nil {
+		info.err = fmt.Errorf("failed to fetch branches: %w", err)
+		return info
+	}
+	for _, head := range heads {
+		info.bySHA[head.Object.SHA] = append(info.bySHA[head.Object.SHA], strings.TrimPrefix(head.Ref, "refs/heads/"))
+	}
+
+	return info
+}
+
+// maxTagPages bounds how many pages of tags are listed when mapping commits
+// to tags. Tags are listed newest first, so older tags beyond the limit only
+// matter for long-outdated pins.
+const maxTagPages = 5
+
+func (c *Client) getTags(repo string) ([]Tag, error) {
+	var all []Tag
+	for page := 1; page <= maxTagPages; page++ {
+		var tags []Tag
+		url := fmt.Sprintf("%s/repos/%s/tags?per_page=100&page=%d", githubAPIURL, repo, page)
+		if err := c.fetchJSON(url, &tags); err != nil {
+			return nil, fmt.Errorf("failed to fetch tags: %w", err)
+		}
+		all = append(all, tags...)
+		if len(tags) < 100 {
+			break
+		}
+	}
+	return all, nil
+}
+
+// ResolveRef resolves a tag or branch name to the commit SHA it points at
+// using the git refs API, peeling annotated tags.
+func (c *Client) ResolveRef(repo, ref string) (string, error) {
+	var gitRef GitRef
+	err := c.fetchJSON(fmt.Sprintf("%s/repos/%s/git/ref/tags/%s", githubAPIURL, repo, ref), &gitRef)
+	if errors.Is(err, errNotFound) {
+		err = c.fetchJSON(fmt.Sprintf("%s/repos/%s/git/ref/heads/%s", githubAPIURL, repo, ref), &gitRef)
+	}
+	if errors.Is(err, errNotFound) {
+		return "", fmt.Errorf("ref '%s' not found in '%s'", ref, repo)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve '%s@%s': %w", repo, ref, err)
+	}
+
+	object := gitRef.Object
+	for object.Type == "tag" {
+		var tag GitTag
+		url := fmt.Sprintf("%s/repos/%s/git/tags/%s", githubAPIURL, repo, object.SHA)
+		if err := c.fetchJSON(url, &tag); err != nil {
+			return "", fmt.Errorf("failed to peel tag '%s': %w", ref, err)
+		}
+		object = tag.Object
+	}
+	return object.SHA, nil
+}
+
+// PinActionRefs rewrites the "uses:" lines of data referenced by audits to
+// "<sha> # <version>", leaving other lines untouched. Audits with errors or
+// nothing to pin to are skipped.
+func PinActionRefs(data []byte, audits []ActionAudit) []byte {
+	byLine := make(map[int]ActionAudit)
+	for _, audit := range audits {
+		if audit.Err == nil && audit.PinTo != "" {
+			byLine[audit.Line] = audit
+		}
+	}
+
+	lines := strings.SplitAfter(string(data), "\n")
+	for i, line := range lines {
+		audit, ok := byLine[i+1]
+		if !ok {
+			continue
+		}
+
+		content := strings.TrimRight(line, "\r\n")
+		eol := line[len(content):]
+		m := usesLine.FindStringSubmatch(content)
+		if m == nil {
+			continue
+		}
+
+		pinned := m[1] + m[2] + audit.Action() + "@" + audit.PinTo + m[4]
+		if audit.PinComment != "" {
+			pinned += " # " + audit.PinComment
+		}
+		lines[i] = pinned + eol
+	}
+
+	return []byte(strings.Join(lines, ""))
+}
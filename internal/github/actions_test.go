@@ -0,0 +1,96 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+const testWorkflow = `name: CI
+on: push
+jobs:
+  reuse:
+    uses: octo/workflows/.github/workflows/build.yml@v2
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@3d3c42e5aac5ba805825da76410c181273ba90b1 # v7.0.1
+      - name: Setup
+        uses: "actions/setup-go@v5"  
+      - uses: ./local-action
+      - uses: docker://alpine:3.20
+      - uses: github/codeql-action/init@main
+`
+
+func TestParseActionRefs(t *testing.T) {
+	refs := ParseActionRefs("ci.yml", []byte(testWorkflow))
+
+	want := []ActionRef{
+		{File: "ci.yml", Line: 5, Repository: "octo/workflows", Path: ".github/workflows/build.yml", Ref: "v2"},
+		{File: "ci.yml", Line: 9, Repository: "actions/checkout", Ref: "3d3c42e5aac5ba805825da76410c181273ba90b1", Comment: "v7.0.1"},
+		{File: "ci.yml", Line: 11, Repository: "actions/setup-go", Ref: "v5"},
+		{File: "ci.yml", Line: 14, Repository: "github/codeql-action", Path: "init", Ref: "main"},
+	}
+
+	if len(refs) != len(want) {
+		t.Fatalf("got %d refs %+v, want %d", len(refs), refs, len(want))
+	}
+	for i := range want {
+		if refs[i] != want[i] {
+			t.Errorf("refs[%d] = %+v, want %+v", i, refs[i], want[i])
+		}
+	}
+	if !IsFullSHA(refs[1].Ref) || IsFullSHA(refs[2].Ref) {
+		t.Errorf("IsFullSHA mismatch")
+	}
+}
+
+func TestPinActionRefs(t *testing.T) {
+	refs := ParseActionRefs("ci.yml", []byte(testWorkflow))
+	sha := "0123456789abcdef0123456789abcdef01234567"
+	audits := []ActionAudit{
+		{ActionRef: refs[2], PinTo: sha, PinComment: "v5.4.0"},
+		{ActionRef: refs[3], Err: errNotFound, PinTo: sha},
+	}
+
+	got := string(PinActionRefs([]byte(testWorkflow), audits))
+	pinned := ParseActionRefs("ci.yml", []byte(got))
+
+	if pinned[2].Ref != sha || pinned[2].Comment != "v5.4.0" || pinned[2].Repository != "actions/setup-go" {
+		t.Errorf("setup-go not pinned: %+v", pinned[2])
+	}
+	if pinned[3].Ref != "main" {
+		t.Errorf("errored reference must be left untouched: %+v", pinned[3])
+	}
+	if pinned[1] != refs[1] {
+		t.Errorf("unrelated line changed: %+v", pinned[1])
+	}
+}
+
+func TestLatestInMajor(t *testing.T) {
+	now := time.Now()
+	var versions []versionTag
+	for _, tc := range []struct{ name, date string }{
+		{"v4", ""},
+		{"v4.1.0", now.Add(-60 * 24 * time.Hour).Format(time.RFC3339)},
+		{"v4.2.0", now.Add(-2 * 24 * time.Hour).Format(time.RFC3339)},
+		{"v4.3.0-rc.1", ""},
+		{"v4.4.0", ""},
+		{"v5.0.0", ""},
+	} {
+		versions = append(versions, versionTag{name: tc.name, version: parseVersionTag(tc.name), date: tc.date})
+	}
+
+	if got := latestInMajor(versions, 4, 0, false, now); got == nil || got.name != "v4.4.0" {
+		t.Errorf("without cooldown got %+v, want v4.4.0", got)
+	}
+	week := 7 * 24 * time.Hour
+	if got := latestInMajor(versions, 4, week, false, now.Add(-week)); got == nil || got.name != "v4.1.0" {
+		t.Errorf("with cooldown got %+v, want v4.1.0", got)
+	}
+	if got := latestInMajor(versions, 4, week, true, now.Add(-week)); got == nil || got.name != "v4.4.0" {
+		t.Errorf("with cooldown allowing undated tags got %+v, want v4.4.0", got)
+	}
+	if got := latestInMajor(versions, 3, 0, false, now); got != nil {
+		t.Errorf("unknown major got %+v, want nil", got)
+	}
+}
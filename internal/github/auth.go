@@ -0,0 +1,388 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultHost is the GitHub host credentials are looked up for.
+const DefaultHost = "github.com"
+
+// Credential is a token found by ResolveCredential, with the name of the
+// source it came from.
+type Credential struct {
+	Token  string
+	Source string
+	// Attempts lists the sources that were tried, in order, up to and
+	// including the one the token came from.
+	Attempts []Attempt
+}
+
+// Attempt is the outcome of looking up a token in one source. Err is set
+// when the source is configured but could not be read; a source that is
+// simply not configured has neither a token nor an error.
+type Attempt struct {
+	Source string
+	Found  bool
+	Err    error
+}
+
+type credentialSource struct {
+	name   string
+	lookup func(host string) (string, error)
+}
+
+// credentialSources returns the sources ResolveCredential tries for host, in
+// order. Like the gh CLI, GH_TOKEN and GITHUB_TOKEN only apply to github.com
+// while GH_ENTERPRISE_TOKEN and GITHUB_ENTERPRISE_TOKEN apply to other hosts.
+func credentialSources(host string) []credentialSource {
+	var sources []credentialSource
+	names := []string{"GH_TOKEN", "GITHUB_TOKEN"}
+	if host != DefaultHost {
+		names = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+	}
+	names = append(names, hostEnvVar(host))
+	for _, name := range names {
+		sources = append(sources, credentialSource{name, func(string) (string, error) {
+			return os.Getenv(name), nil
+		}})
+	}
+
+	return append(sources,
+		credentialSource{"netrc", netrcToken},
+		credentialSource{"gh", ghToken},
+		credentialSource{"git credential", gitCredentialToken},
+	)
+}
+
+// hostEnvVar is the per-host environment variable holding a token for host,
+// e.g. GITHUB_TOKEN_GITHUB_EXAMPLE_COM for github.example.com.
+func hostEnvVar(host string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, host)
+	return "GITHUB_TOKEN_" + name
+}
+
+// ResolveCredential looks up a token for host in the environment, ~/.netrc,
+// the gh CLI configuration and the git credential helpers, stopping at the
+// first source that has one. Sources that fail are recorded in the
+// attempts and skipped.
+func ResolveCredential(host string) Credential {
+	var cred Credential
+	for _, source := range credentialSources(host) {
+		token, err := source.lookup(host)
+		token = strings.TrimSpace(token)
+		cred.Attempts = append(cred.Attempts, Attempt{Source: source.name, Found: token != "", Err: err})
+		if token != "" {
+			cred.Token = token
+			cred.Source = source.name
+			break
+		}
+	}
+	return cred
+}
+
+func netrcPath() string {
+	if path := os.Getenv("NETRC"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".netrc")
+}
+
+// netrcToken returns the password of the netrc entry for host or its API
+// host, e.g. api.github.com. Entries for the API host take precedence.
+func netrcToken(host string) (string, error) {
+	path := netrcPath()
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	entries := parseNetrc(data)
+	for _, machine := range []string{"api." + host, host} {
+		if password := entries[machine]; password != "" {
+			return password, nil
+		}
+	}
+	return "", nil
+}
+
+// parseNetrc maps machine names to their passwords. Macro definitions and
+// the default entry are ignored.
+func parseNetrc(data []byte) map[string]string {
+	entries := make(map[string]string)
+
+	var tokens []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		tokens = append(tokens, strings.Fields(line)...)
+	}
+
+	machine := ""
+	for i := 0; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "machine":
+			machine = ""
+			if i+1 < len(tokens) {
+				machine = tokens[i+1]
+				i++
+			}
+		case "default":
+			machine = ""
+		case "login", "account":
+			i++
+		case "password":
+			if i+1 < len(tokens) && machine != "" {
+				if _, ok := entries[machine]; !ok {
+					entries[machine] = tokens[i+1]
+				}
+			}
+			i++
+		case "macdef":
+			// Macros run until the next blank line, which the tokenizer
+			// does not preserve; stop rather than misread them.
+			return entries
+		}
+	}
+	return entries
+}
+
+func ghConfigDir() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh")
+}
+
+// ghToken reads the token the gh CLI stores for host in hosts.yml. Recent
+// versions of gh keep the token in the system keyring instead, in which
+// case gh itself is asked for it. gh is only run for hosts it is logged in
+// to.
+func ghToken(host string) (string, error) {
+	dir := ghConfigDir()
+	if dir == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "hosts.yml"))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	token, ok := parseGhHosts(data, host)
+	if !ok || token != "" {
+		return token, nil
+	}
+
+	cmd := exec.Command("gh", "auth", "token", "--hostname", host)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("gh auth token: %s", msg)
+		}
+		return "", fmt.Errorf("gh auth token: %w", err)
+	}
+	return string(output), nil
+}
+
+// parseGhHosts finds host in a gh hosts.yml file and returns the oauth_token
+// directly under it. It reports whether the host is present at all.
+func parseGhHosts(data []byte, host string) (string, bool) {
+	found := false
+	childIndent := -1
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		key, value, _ := strings.Cut(trimmed, ":")
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		if indent == 0 {
+			if found {
+				break
+			}
+			found = strings.Trim(key, `"'`) == host
+			continue
+		}
+		if !found {
+			continue
+		}
+		if childIndent < 0 {
+			childIndent = indent
+		}
+		if indent == childIndent && key == "oauth_token" {
+			return value, true
+		}
+	}
+	return "", found
+}
+
+// gitCredentialToken asks the configured git credential helpers for the
+// password of https://<host>. Prompting is disabled, so hosts without a
+// stored credential yield nothing.
+func gitCredentialToken(host string) (string, error) {
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("protocol=https\nhost=%s\n\n", host))
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=true", "GCM_INTERACTIVE=never")
+	output, err := cmd.Output()
+	if err != nil {
+		// git is missing or no helper has a credential for the host.
+		return "", nil
+	}
+
+	for line := range strings.Lines(string(output)) {
+		if password, ok := strings.CutPrefix(strings.TrimSpace(line), "password="); ok {
+			return password, nil
+		}
+	}
+	return "", nil
+}
+
+// APIURL returns the REST API base URL of host.
+func APIURL(host string) string {
+	if host == DefaultHost {
+		return githubAPIURL
+	}
+	return "https://" + host + "/api/v3"
+}
+
+// AuthStatus describes the credential used for a host, as reported by
+// GetAuthStatus. It never contains the token itself.
+type AuthStatus struct {
+	Host      string
+	Source    string
+	TokenType string
+	Login     string
+	// Scopes are the OAuth scopes of classic tokens. Fine-grained tokens
+	// have permissions instead and report none.
+	Scopes []string
+	// Expiration is the expiry reported by GitHub, if the token has one.
+	Expiration string
+	Attempts   []Attempt
+}
+
+// GetAuthStatus resolves the credential for host and asks the API what it
+// grants. It returns an error when no token is found or the API rejects it,
+// along with the status gathered so far.
+func GetAuthStatus(host string) (*AuthStatus, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	return getAuthStatus(client, APIURL(host), host, ResolveCredential(host))
+}
+
+func getAuthStatus(client *http.Client, apiURL, host string, cred Credential) (*AuthStatus, error) {
+	status := &AuthStatus{
+		Host:      host,
+		Source:    cred.Source,
+		TokenType: tokenType(cred.Token),
+		Attempts:  cred.Attempts,
+	}
+	if cred.Token == "" {
+		return status, fmt.Errorf("no GitHub token found for %s", host)
+	}
+
+	req, err := http.NewRequest("GET", apiURL+"/user", nil)
+	if err != nil {
+		return status, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("token %s", cred.Token))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return status, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if scopes := resp.Header.Get("X-OAuth-Scopes"); scopes != "" {
+		for _, scope := range strings.Split(scopes, ",") {
+			status.Scopes = append(status.Scopes, strings.TrimSpace(scope))
+		}
+	}
+	status.Expiration = resp.Header.Get("GitHub-Authentication-Token-Expiration")
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return status, fmt.Errorf("token from %s is invalid or expired", cred.Source)
+	case http.StatusForbidden:
+		// Installation tokens cannot read /user but are otherwise valid.
+		return status, nil
+	default:
+		return status, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return status, fmt.Errorf("failed to decode response: %w", err)
+	}
+	status.Login = user.Login
+	return status, nil
+}
+
+// tokenType names the kind of token from its prefix.
+func tokenType(token string) string {
+	switch {
+	case token == "":
+		return ""
+	case strings.HasPrefix(token, "github_pat_"):
+		return "fine-grained personal access token"
+	case strings.HasPrefix(token, "ghp_"):
+		return "classic personal access token"
+	case strings.HasPrefix(token, "gho_"):
+		return "OAuth token"
+	case strings.HasPrefix(token, "ghu_"):
+		return "GitHub App user token"
+	case strings.HasPrefix(token, "ghs_"):
+		return "GitHub App installation token"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,146 @@
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseNetrc(t *testing.T) {
+	data := []byte(`# comment
+machine api.github.com login x-access-token password ghp_api
+machine github.com
+  login user
+  password ghp_web
+default login anonymous password secret
+`)
+	got := parseNetrc(data)
+	want := map[string]string{"api.github.com": "ghp_api", "github.com": "ghp_web"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseNetrc() = %v, want %v", got, want)
+	}
+}
+
+func TestParseGhHosts(t *testing.T) {
+	data := []byte(`github.com:
+    users:
+        alice:
+            oauth_token: gho_nested
+    git_protocol: https
+    user: alice
+    oauth_token: gho_alice
+ghe.example.com:
+    user: bob
+    git_protocol: ssh
+`)
+
+	tests := []struct {
+		host      string
+		wantToken string
+		wantFound bool
+	}{
+		{"github.com", "gho_alice", true},
+		{"ghe.example.com", "", true},
+		{"other.example.com", "", false},
+	}
+	for _, tt := range tests {
+		token, found := parseGhHosts(data, tt.host)
+		if token != tt.wantToken || found != tt.wantFound {
+			t.Errorf("parseGhHosts(%q) = %q, %v, want %q, %v", tt.host, token, found, tt.wantToken, tt.wantFound)
+		}
+	}
+}
+
+func TestResolveCredential(t *testing.T) {
+	dir := t.TempDir()
+	// Keep gh and git out of reach so that only files and env are consulted.
+	t.Setenv("PATH", "")
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN_GITHUB_COM", "")
+	t.Setenv("GH_CONFIG_DIR", dir)
+	t.Setenv("NETRC", filepath.Join(dir, "netrc"))
+
+	cred := ResolveCredential(DefaultHost)
+	if cred.Token != "" || len(cred.Attempts) != 6 {
+		t.Fatalf("expected no token after 6 attempts, got %+v", cred)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte("github.com:\n  oauth_token: gho_gh\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if cred := ResolveCredential(DefaultHost); cred.Token != "gho_gh" || cred.Source != "gh" {
+		t.Errorf("got %q from %q, want gho_gh from gh", cred.Token, cred.Source)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "netrc"), []byte("machine github.com password ghp_netrc\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if cred := ResolveCredential(DefaultHost); cred.Token != "ghp_netrc" || cred.Source != "netrc" {
+		t.Errorf("got %q from %q, want ghp_netrc from netrc", cred.Token, cred.Source)
+	}
+
+	t.Setenv("GITHUB_TOKEN_GITHUB_COM", "ghp_host")
+	t.Setenv("GITHUB_TOKEN", "ghp_env")
+	cred = ResolveCredential(DefaultHost)
+	if cred.Token != "ghp_env" || cred.Source != "GITHUB_TOKEN" || len(cred.Attempts) != 2 {
+		t.Errorf("got %+v, want ghp_env from GITHUB_TOKEN after 2 attempts", cred)
+	}
+
+	// GITHUB_TOKEN only applies to github.com.
+	cred = ResolveCredential("ghe.example.com")
+	if cred.Token != "" {
+		t.Errorf("unexpected credential for enterprise host: %+v", cred)
+	}
+	t.Setenv("GITHUB_TOKEN_GHE_EXAMPLE_COM", "ghp_ghe")
+	if cred := ResolveCredential("ghe.example.com"); cred.Token != "ghp_ghe" || cred.Source != "GITHUB_TOKEN_GHE_EXAMPLE_COM" {
+		t.Errorf("got %q from %q, want ghp_ghe from GITHUB_TOKEN_GHE_EXAMPLE_COM", cred.Token, cred.Source)
+	}
+}
+
+func TestGetAuthStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Header.Get("Authorization") {
+		case "token github_pat_ok":
+			w.Header().Set("GitHub-Authentication-Token-Expiration", "2026-12-01 00:00:00 UTC")
+			_, _ = w.Write([]byte(`{"login": "alice"}`))
+		case "token ghp_ok":
+			w.Header().Set("X-OAuth-Scopes", "repo, read:org")
+			_, _ = w.Write([]byte(`{"login": "bob"}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	status, err := getAuthStatus(srv.Client(), srv.URL, DefaultHost, Credential{Token: "github_pat_ok", Source: "GH_TOKEN"})
+	if err != nil {
+		t.Fatalf("getAuthStatus() error = %v", err)
+	}
+	if status.Login != "alice" || status.Expiration != "2026-12-01 00:00:00 UTC" ||
+		status.TokenType != "fine-grained personal access token" || len(status.Scopes) != 0 {
+		t.Errorf("unexpected status for fine-grained token: %+v", status)
+	}
+
+	status, err = getAuthStatus(srv.Client(), srv.URL, DefaultHost, Credential{Token: "ghp_ok", Source: "netrc"})
+	if err != nil {
+		t.Fatalf("getAuthStatus() error = %v", err)
+	}
+	if status.Login != "bob" || !reflect.DeepEqual(status.Scopes, []string{"repo", "read:org"}) {
+		t.Errorf("unexpected status for classic token: %+v", status)
+	}
+
+	if _, err := getAuthStatus(srv.Client(), srv.URL, DefaultHost, Credential{Token: "ghp_bad", Source: "gh"}); err == nil {
+		t.Error("expected error for a rejected token")
+	}
+	if _, err := getAuthStatus(srv.Client(), srv.URL, DefaultHost, Credential{}); err == nil {
+		t.Error("expected error without a token")
+	}
+}
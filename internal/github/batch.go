@@ -0,0 +1,189 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultBatchSize is the number of repositories packed into a single
+// aliased GraphQL query. GitHub limits the query cost rather than the number
+// of aliases, and 50 repositories with a handful of nodes each stays well
+// below the limit.
+const DefaultBatchSize = 50
+
+// LatestResult is the outcome of a latest-version lookup for one repository
+// of a batch. Exactly one of Version and Err is set.
+type LatestResult struct {
+	Repository string
+	Version    string
+	Err        error
+}
+
+// GetLatestVersions resolves the latest version of every repository in names,
+// calling fn once per chunk of results as soon as the chunk completes. Errors
+// specific to a repository are reported in its [LatestResult] and do not stop
+// the batch; an error returned by fn does.
+//
+// With a token and no options, repositories are packed batchSize at a time
+// into aliased GraphQL queries. Otherwise, each repository is resolved with
+// [Client.GetLatestVersionWithOptions] and batchSize only sets how often fn
+// is called.
+func (c *Client) GetLatestVersions(names []string, batchSize int, opts LatestOptions, fn func([]LatestResult) error) error {
+	if c.authToken() != "" && !c.forceREST && opts == (LatestOptions{}) {
+		return forEachChunk(names, batchSize, c.getLatestVersionsGraphQL, fn)
+	}
+	return forEachChunk(names, batchSize, resolveEach(func(name string) (string, error) {
+		return c.GetLatestVersionWithOptions(name, opts)
+	}), fn)
+}
+
+func forEachChunk(names []string, batchSize int, resolve func([]string) []LatestResult, fn func([]LatestResult) error) error {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	for start := 0; start < len(names); start += batchSize {
+		chunk := names[start:min(start+batchSize, len(names))]
+		if err := fn(resolve(chunk)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveEach adapts a single-repository lookup to resolve a chunk.
+func resolveEach(latest func(string) (string, error)) func([]string) []LatestResult {
+	return func(names []string) []LatestResult {
+		results := make([]LatestResult, len(names))
+		for i, name := range names {
+			version, err := latest(name)
+			results[i] = LatestResult{Repository: name, Version: version, Err: err}
+		}
+		return results
+	}
+}
+
+func (c *Client) getLatestVersionsGraphQL(names []string) []LatestResult {
+	results := make([]LatestResult, len(names))
+	for i, name := range names {
+		results[i].Repository = name
+	}
+
+	query, variables, aliases := buildLatestBatchQuery(names)
+	for i, name := range names {
+		if _, ok := aliases[batchAlias(i)]; !ok {
+			_, _, err := parseOwnerRepo(name)
+			results[i].Err = err
+		}
+	}
+	if len(aliases) == 0 {
+		return results
+	}
+
+	response, err := c.executeGraphQLQuery(query, variables)
+	if err != nil {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = err
+			}
+		}
+		return results
+	}
+
+	errorsByAlias := make(map[string]string)
+	var globalErr error
+	for _, gqlErr := range response.Errors {
+		alias := gqlErr.Alias()
+		if alias == "" {
+			globalErr = fmt.Errorf("GraphQL error: %s", gqlErr.Message)
+			continue
+		}
+		if _, seen := errorsByAlias[alias]; !seen {
+			errorsByAlias[alias] = gqlErr.Message
+		}
+	}
+
+	for alias, i := range aliases {
+		name := names[i]
+		repo := response.Data[alias]
+		switch {
+		case repo != nil:
+			if version := latestFromRepository(repo); version != "" {
+				results[i].Version = version
+			} else {
+				results[i].Err = fmt.Errorf("no versions found for repository '%s'", name)
+			}
+		case errorsByAlias[alias] != "":
+			results[i].Err = fmt.Errorf("repository '%s': %s", name, errorsByAlias[alias])
+		case globalErr != nil:
+			results[i].Err = globalErr
+		default:
+			results[i].Err = fmt.Errorf("repository '%s' not found", name)
+		}
+	}
+
+	return results
+}
+
+// buildLatestBatchQuery packs names into a single GraphQL query with one
+// aliased repository field per entry. It returns the query, its variables and
+// the alias of every well-formed name mapped to its index in names; malformed
+// names are left out of the query.
+func buildLatestBatchQuery(names []string) (string, map[string]any, map[string]int) {
+	var (
+		params    []string
+		fields    strings.Builder
+		variables = make(map[string]any)
+		aliases   = make(map[string]int)
+	)
+
+	for i, name := range names {
+		owner, repoName, err := parseOwnerRepo(name)
+		if err != nil {
+			continue
+		}
+
+		alias := batchAlias(i)
+		aliases[alias] = i
+		variables[fmt.Sprintf("o%d", i)] = owner
+		variables[fmt.Sprintf("n%d", i)] = repoName
+		params = append(params, fmt.Sprintf("$o%d: String!, $n%d: String!", i, i))
+		fmt.Fprintf(&fields, "\t%s: repository(owner: $o%d, name: $n%d) { ...latest }\n", alias, i, i)
+	}
+
+	query := fmt.Sprintf(`
+		query(%s) {
+%s		}
+
+		fragment latest on Repository {
+			nameWithOwner
+			latestRelease { tagName isPrerelease publishedAt }
+			releases(first: %d, orderBy: {field: CREATED_AT, direction: DESC}) {
+				nodes { tagName isPrerelease publishedAt }
+			}
+			refs(refPrefix: "refs/tags/", first: %d, orderBy: {field: TAG_COMMIT_DATE, direction: DESC}) {
+				nodes { name }
+			}
+		}
+	`, strings.Join(params, ", "), fields.String(), versionsPageSize, versionsPageSize)
+
+	return query, variables, aliases
+}
+
+func batchAlias(i int) string {
+	return fmt.Sprintf("r%d", i)
+}
+
+// latestFromRepository mirrors [Client.GetLatestVersion]: the release GitHub
+// marks as latest, then the newest non-prerelease release, then the newest
+// tag.
+func latestFromRepository(repo *GraphQLRepository) string {
+	if repo.LatestRelease != nil && !repo.LatestRelease.IsPrerelease && repo.LatestRelease.TagName != "" {
+		return repo.LatestRelease.TagName
+	}
+	if versions := repositoryVersions(repo); len(versions) > 0 {
+		return versions[0].Version
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestBuildLatestBatchQuery(t *testing.T) {
+	query, variables, aliases := buildLatestBatchQuery([]string{"a/b", "invalid", "c/d"})
+
+	if len(aliases) != 2 || aliases["r0"] != 0 || aliases["r2"] != 2 {
+		t.Fatalf("aliases = %v, want r0->0 and r2->2", aliases)
+	}
+	if _, ok := aliases["r1"]; ok {
+		t.Errorf("malformed name must not get an alias")
+	}
+	if variables["o0"] != "a" || variables["n0"] != "b" || variables["o2"] != "c" || variables["n2"] != "d" {
+		t.Errorf("variables = %v", variables)
+	}
+	for _, want := range []string{
+		"$o0: String!, $n0: String!, $o2: String!, $n2: String!",
+		"r0: repository(owner: $o0, name: $n0)",
+		"r2: repository(owner: $o2, name: $n2)",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query does not contain %q:\n%s", want, query)
+		}
+	}
+}
+
+func TestAliasedResponseDecoding(t *testing.T) {
+	body := `{
+		"data": {
+			"r0": {
+				"nameWithOwner": "a/b",
+				"latestRelease": {"tagName": "v2.0.0", "isPrerelease": false},
+				"releases": {"nodes": [{"tagName": "v2.1.0-rc.1", "isPrerelease": true}]},
+				"refs": {"nodes": [{"name": "v2.1.0-rc.1"}]}
+			},
+			"r1": {
+				"nameWithOwner": "c/d",
+				"latestRelease": null,
+				"releases": {"nodes": [
+					{"tagName": "v1.1.0-beta", "isPrerelease": true},
+					{"tagName": "v1.0.0", "isPrerelease": false}
+				]},
+				"refs": {"nodes": []}
+			},
+			"r2": {
+				"nameWithOwner": "e/f",
+				"latestRelease": null,
+				"releases": {"nodes": []},
+				"refs": {"nodes": [{"name": "0.3.0", "target": {"tagger": {"date": "2024-01-02T00:00:00Z"}}}]}
+			},
+			"r3": null
+		},
+		"errors": [
+			{"type": "NOT_FOUND", "path": ["r3"], "message": "Could not resolve to a Repository with the name 'g/h'."},
+			{"type": "SOME_ERROR", "path": ["r2", "refs", "nodes", 0], "message": "partial"}
+		]
+	}`
+
+	var response GraphQLResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	tests := map[string]string{"r0": "v2.0.0", "r1": "v1.0.0", "r2": "0.3.0"}
+	for alias, want := range tests {
+		if got := latestFromRepository(response.Data[alias]); got != want {
+			t.Errorf("%s: got %q, want %q", alias, got, want)
+		}
+	}
+
+	if response.Data["r3"] != nil {
+		t.Errorf("r3 should decode to nil")
+	}
+	if got := response.Errors[0].Alias(); got != "r3" {
+		t.Errorf("Alias() = %q, want r3", got)
+	}
+	if got := response.Errors[1].Alias(); got != "r2" {
+		t.Errorf("Alias() = %q, want r2", got)
+	}
+	if got := response.Data["r2"].Refs.Nodes[0].Date(); got != "2024-01-02T00:00:00Z" {
+		t.Errorf("Date() = %q", got)
+	}
+}
+
+// TestLatestPathsAgree checks that the batched query fetches as many releases
+// and tags as the single-repository one, and that both pick the same version
+// from what they fetch.
+func TestLatestPathsAgree(t *testing.T) {
+	pageSizes := regexp.MustCompile(`(releases|refs)\([^)]*first: (\d+)`)
+	sizes := func(query string) map[string]string {
+		got := make(map[string]string)
+		for _, m := range pageSizes.FindAllStringSubmatch(query, -1) {
+			got[m[1]] = m[2]
+		}
+		return got
+	}
+	batch, _, _ := buildLatestBatchQuery([]string{"a/b"})
+	for _, withSignatures := range []bool{false, true} {
+		single := sizes(versionsQuery(withSignatures))
+		if fmt.Sprint(sizes(batch)) != fmt.Sprint(single) || len(single) != 2 {
+			t.Errorf("batch page sizes %v, single-repository page sizes %v", sizes(batch), single)
+		}
+	}
+
+	repo := &GraphQLRepository{
+		Releases: &GraphQLConnection[GraphQLReleaseNode]{},
+		Refs:     &GraphQLConnection[GraphQLRefNode]{Nodes: []GraphQLRefNode{{Name: "v3.0.0-rc.12"}, {Name: "v2.0.0"}}},
+	}
+	for i := 12; i > 0; i-- {
+		repo.Releases.Nodes = append(repo.Releases.Nodes, GraphQLReleaseNode{TagName: fmt.Sprintf("v3.0.0-rc.%d", i), IsPrerelease: true})
+	}
+	repo.Releases.Nodes = append(repo.Releases.Nodes, GraphQLReleaseNode{TagName: "v2.0.0"})
+
+	if got, want := latestFromRepository(repo), repositoryVersions(repo)[0].Version; got != want || got != "v2.0.0" {
+		t.Errorf("batched latest %q, single-repository latest %q, want v2.0.0", got, want)
+	}
+}
@@ -3,11 +3,13 @@ package github
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
-	"os/exec"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/acidghost/a555pq/internal/formatter"
@@ -16,36 +18,56 @@ import (
 const githubAPIURL = "https://api.github.com"
 const graphqlAPIURL = "https://api.github.com/graphql"
 
+// errNotFound is wrapped by fetchJSON, along with the resource requested,
+// when the API responds with 404.
+var errNotFound = errors.New("not found")
+
+// errForbidden is wrapped by fetchJSON when the API responds with 403 for
+// reasons other than the rate limit, typically a token lacking a scope.
+var errForbidden = errors.New("forbidden")
+
+// ErrNoVersions is wrapped by the latest-version lookups when a repository
+// has neither releases nor tags.
+var ErrNoVersions = errors.New("no versions found")
+
 type Client struct {
 	httpClient *http.Client
-	token      string
 	forceREST  bool
-}
-
-func getGitHubToken() string {
-	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
-		return token
-	}
 
-	cmd := exec.Command("gh", "auth", "token")
-	output, err := cmd.Output()
-	if err != nil {
-		return ""
-	}
-	return strings.TrimSpace(string(output))
+	// The token is resolved on first use, so that commands which never
+	// call the API do not pay for the credential lookup.
+	tokenOnce sync.Once
+	token     string
 }
 
 func NewClient(forceREST bool) *Client {
-	token := getGitHubToken()
 	return &Client{
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		token:     token,
 		forceREST: forceREST,
 	}
 }
 
+// authToken returns the token requests are authenticated with, resolving it
+// with ResolveCredential on first use. Sources that fail to be read are
+// reported as warnings.
+func (c *Client) authToken() string {
+	c.tokenOnce.Do(func() {
+		if c.token != "" {
+			return
+		}
+		cred := ResolveCredential(DefaultHost)
+		for _, attempt := range cred.Attempts {
+			if attempt.Err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to read GitHub token from %s: %v\n", attempt.Source, attempt.Err)
+			}
+		}
+		c.token = cred.Token
+	})
+	return c.token
+}
+
 func (c *Client) GetPackageInfo(name string) (*Repository, error) {
 	url := fmt.Sprintf("%s/repos/%s", githubAPIURL, name)
 
@@ -54,8 +76,8 @@ func (c *Client) GetPackageInfo(name string) (*Repository, error) {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	if c.token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("token %s", c.token))
+	if c.authToken() != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("token %s", c.authToken()))
 	}
 
 	resp, err := c.httpClient.Do(req)
@@ -85,12 +107,116 @@ func (c *Client) GetPackageInfo(name string) (*Repository, error) {
 }
 
 func (c *Client) GetVersions(name string) ([]formatter.VersionItem, error) {
-	if c.token != "" && !c.forceREST {
-		return c.getVersionsGraphQL(name)
+	if c.authToken() != "" && !c.forceREST {
+		return c.getVersionsGraphQL(name, false)
 	}
 	return c.getVersionsREST(name)
 }
 
+// GetVersionsWithSignatures is like GetVersions but also reports the
+// signature verification status of every version: the signature of the tag
+// object for annotated tags, or of the tagged commit for lightweight ones.
+// Signatures are only exposed by the GraphQL API, so a token is required.
+func (c *Client) GetVersionsWithSignatures(name string) ([]formatter.VersionItem, error) {
+	if c.authToken() == "" || c.forceREST {
+		return nil, fmt.Errorf("signature verification requires the GraphQL API, set GITHUB_TOKEN environment variable")
+	}
+	return c.getVersionsGraphQL(name, true)
+}
+
+// VersionsOptions selects what ListVersions reports.
+type VersionsOptions struct {
+	// Signatures adds the signature verification status of every version,
+	// see [Client.GetVersionsWithSignatures].
+	Signatures bool
+	// Tags restricts the versions to the tags it matches, ordered by the
+	// version extracted from them.
+	Tags *TagMatcher
+}
+
+// ListVersions lists the versions of a repository like GetVersions, with the
+// additions requested in opts. When filtering tags, all tags are listed
+// rather than only the most recent ones, so that components released less
+// often are not missed; tags that are not among the most recent ones have no
+// date or verification status.
+func (c *Client) ListVersions(name string, opts VersionsOptions) ([]formatter.VersionItem, error) {
+	var (
+		versions []formatter.VersionItem
+		err      error
+	)
+	if opts.Signatures {
+		versions, err = c.GetVersionsWithSignatures(name)
+	} else {
+		versions, err = c.GetVersions(name)
+	}
+	if err != nil || opts.Tags == nil {
+		return versions, err
+	}
+
+	tags, err := c.getTags(name)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]struct{}, len(versions))
+	for _, v := range versions {
+		known[v.Version] = struct{}{}
+	}
+	for _, tag := range tags {
+		if _, ok := known[tag.Name]; !ok {
+			versions = append(versions, formatter.VersionItem{Version: tag.Name})
+			known[tag.Name] = struct{}{}
+		}
+	}
+
+	return opts.Tags.Filter(versions), nil
+}
+
+// LatestOptions narrows down the versions GetLatestVersionWithOptions
+// considers.
+type LatestOptions struct {
+	// RequireSigned skips versions without a valid signature.
+	RequireSigned bool
+	// Tags restricts the lookup to the tags it matches. The highest stable
+	// extracted version wins.
+	Tags *TagMatcher
+}
+
+// GetLatestVersionWithOptions is like GetLatestVersion, restricted to the
+// versions allowed by opts. Without options it is GetLatestVersion.
+func (c *Client) GetLatestVersionWithOptions(name string, opts LatestOptions) (string, error) {
+	if !opts.RequireSigned && opts.Tags == nil {
+		return c.GetLatestVersion(name)
+	}
+
+	versions, err := c.ListVersions(name, VersionsOptions{Signatures: opts.RequireSigned, Tags: opts.Tags})
+	if err != nil {
+		return "", err
+	}
+
+	var candidates []formatter.VersionItem
+	for _, v := range versions {
+		if !opts.RequireSigned || isVerified(v.Verification) {
+			candidates = append(candidates, v)
+		}
+	}
+
+	if opts.Tags != nil {
+		for _, v := range candidates {
+			if version := opts.Tags.Version(v.Version); version != nil && version.Prerelease() == "" {
+				return v.Version, nil
+			}
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates[0].Version, nil
+	}
+
+	if opts.RequireSigned {
+		return "", fmt.Errorf("no signed versions found for repository '%s'", name)
+	}
+	return "", fmt.Errorf("no versions found for repository '%s'", name)
+}
+
 func (c *Client) getVersionsREST(name string) ([]formatter.VersionItem, error) {
 	var (
 		releaseURL = fmt.Sprintf("%s/repos/%s/releases", githubAPIURL, name)
@@ -100,7 +226,11 @@ func (c *Client) getVersionsREST(name string) ([]formatter.VersionItem, error) {
 		tags     []Tag
 	)
 
-	if err := c.fetchJSON(releaseURL, &releases); err != nil {
+	err := c.fetchJSON(releaseURL, &releases)
+	if errors.Is(err, errNotFound) {
+		return nil, fmt.Errorf("repository '%s' not found", name)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to fetch releases: %w", err)
 	}
 
@@ -136,33 +266,88 @@ func (c *Client) getVersionsREST(name string) ([]formatter.VersionItem, error) {
 	return versions, nil
 }
 
-func (c *Client) getVersionsGraphQL(name string) ([]formatter.VersionItem, error) {
+// signatureFields selects the signature of a Tag or Commit object.
+const signatureFields = `signature { isValid state signer { login } }`
+
+// versionsPageSize is the number of releases and of tags the GraphQL
+// version queries fetch. The batched latest lookup uses it too, so that it
+// picks the same version as [Client.GetLatestVersion].
+const versionsPageSize = 100
+
+func (c *Client) getVersionsGraphQL(name string, withSignatures bool) ([]formatter.VersionItem, error) {
 	owner, repoName, err := parseOwnerRepo(name)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `
+	variables := map[string]any{
+		"owner": owner,
+		"name":  repoName,
+	}
+
+	response, err := c.executeGraphQLQuery(versionsQuery(withSignatures), variables)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("GraphQL error: %v", response.Errors[0].Message)
+	}
+
+	repo := response.Data["repository"]
+	if repo == nil {
+		return nil, fmt.Errorf("repository '%s' not found", name)
+	}
+
+	versions := repositoryVersions(repo)
+	if withSignatures {
+		addVerification(repo, versions)
+	}
+	return versions, nil
+}
+
+// versionsQuery builds the query for the releases and tags of a single
+// repository, with signature details when withSignatures is set.
+func versionsQuery(withSignatures bool) string {
+	releaseFields, tagFields, commitFields := "", "", ""
+	if withSignatures {
+		releaseFields = `
+						tag {
+							target {
+								__typename
+								... on Tag { ` + signatureFields + ` }
+							}
+						}
+						tagCommit { ` + signatureFields + ` }`
+		tagFields = signatureFields
+		commitFields = signatureFields
+	}
+
+	pageSize := strconv.Itoa(versionsPageSize)
+	return `
 		query($owner: String!, $name: String!) {
 			repository(owner: $owner, name: $name) {
-				releases(first: 100, orderBy: {field: CREATED_AT, direction: DESC}) {
+				releases(first: ` + pageSize + `, orderBy: {field: CREATED_AT, direction: DESC}) {
 					nodes {
 						tagName
 						isPrerelease
 						createdAt
-						publishedAt
+						publishedAt` + releaseFields + `
 					}
 				}
-				refs(refPrefix: "refs/tags/", first: 100, orderBy: {field: TAG_COMMIT_DATE, direction: DESC}) {
+				refs(refPrefix: "refs/tags/", first: ` + pageSize + `, orderBy: {field: TAG_COMMIT_DATE, direction: DESC}) {
 					nodes {
 						name
 						target {
+							__typename
 							... on Commit {
 								author { date }
 								committer { date }
+								` + commitFields + `
 							}
 							... on Tag {
 								tagger { date }
+								` + tagFields + `
 								target {
 									... on Commit {
 										author { date }
@@ -175,101 +360,52 @@ func (c *Client) getVersionsGraphQL(name string) ([]formatter.VersionItem, error
 			}
 		}
 	`
+}
 
-	variables := map[string]any{
-		"owner": owner,
-		"name":  repoName,
-	}
-
-	response, err := c.executeGraphQLQuery(query, variables)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("GraphQL error: %v", response.Errors[0].Message)
-	}
-
-	if response.Data == nil || response.Data.Repository == nil {
-		return nil, fmt.Errorf("repository '%s' not found", name)
-	}
-
-	releaseTags := make(map[string]string)
-	var versions []formatter.VersionItem
-
-	if response.Data.Repository.Releases != nil && len(response.Data.Repository.Releases.Nodes) > 0 {
-		for _, node := range response.Data.Repository.Releases.Nodes {
-			release, ok := node.(map[string]any)
-			if !ok {
-				continue
-			}
-
-			tagName, _ := release["tagName"].(string)
-			isPrerelease, _ := release["isPrerelease"].(bool)
-			publishedAt, _ := release["publishedAt"].(string)
+// repositoryVersions flattens the non-prerelease releases and the remaining
+// tags of a GraphQL repository node into version items.
+func repositoryVersions(repo *GraphQLRepository) []formatter.VersionItem {
+	releaseTags := make(map[string]struct{})
+	versions := []formatter.VersionItem{}
 
-			if !isPrerelease && tagName != "" {
+	if repo.Releases != nil {
+		for _, release := range repo.Releases.Nodes {
+			if !release.IsPrerelease && release.TagName != "" {
 				versions = append(versions, formatter.VersionItem{
-					Version:    tagName,
-					UploadDate: publishedAt,
+					Version:    release.TagName,
+					UploadDate: release.PublishedAt,
 				})
-				releaseTags[tagName] = publishedAt
+				releaseTags[release.TagName] = struct{}{}
 			}
 		}
 	}
 
-	if response.Data.Repository.Refs != nil {
-		for _, node := range response.Data.Repository.Refs.Nodes {
-			ref, ok := node.(map[string]any)
-			if !ok {
-				continue
-			}
-
-			name, _ := ref["name"].(string)
-			if _, exists := releaseTags[name]; exists {
+	if repo.Refs != nil {
+		for _, ref := range repo.Refs.Nodes {
+			if _, exists := releaseTags[ref.Name]; exists || ref.Name == "" {
 				continue
 			}
-
-			var date string
-			if target, ok := ref["target"].(map[string]any); ok {
-				if tagger, ok := target["tagger"].(map[string]any); ok {
-					if d, ok := tagger["date"].(string); ok && d != "" {
-						date = d
-					}
-				}
-				if date == "" {
-					if author, ok := target["author"].(map[string]any); ok {
-						if d, ok := author["date"].(string); ok {
-							date = d
-						}
-					}
-				}
-				if date == "" {
-					if committer, ok := target["committer"].(map[string]any); ok {
-						if d, ok := committer["date"].(string); ok {
-							date = d
-						}
-					}
-				}
-			}
-
-			if name != "" {
-				versions = append(versions, formatter.VersionItem{
-					Version:    name,
-					UploadDate: date,
-				})
-			}
+			versions = append(versions, formatter.VersionItem{
+				Version:    ref.Name,
+				UploadDate: ref.Date(),
+			})
 		}
 	}
 
-	if len(versions) == 0 {
-		return []formatter.VersionItem{}, nil
-	}
-
-	return versions, nil
+	return versions
 }
 
 func (c *Client) executeGraphQLQuery(query string, variables map[string]any) (*GraphQLResponse, error) {
+	var response GraphQLResponse
+	if err := c.executeGraphQL(query, variables, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// executeGraphQL runs query and decodes the whole response envelope into v,
+// for queries whose data is not keyed by repository.
+func (c *Client) executeGraphQL(query string, variables map[string]any, v any) error {
 	requestBody := map[string]any{
 		"query":     query,
 		"variables": variables,
@@ -277,43 +413,42 @@ func (c *Client) executeGraphQLQuery(query string, variables map[string]any) (*G
 
 	bodyBytes, err := json.Marshal(requestBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequest("POST", graphqlAPIURL, bytes.NewBuffer(bodyBytes))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	if c.token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	if c.authToken() != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.authToken()))
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute GraphQL query: %w", err)
+		return fmt.Errorf("failed to execute GraphQL query: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusUnauthorized {
-		return nil, fmt.Errorf("authentication failed, check GITHUB_TOKEN")
+		return fmt.Errorf("authentication failed, run 'a555pq auth status' to check the GitHub token")
 	}
 
 	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("rate limit exceeded")
+		return fmt.Errorf("rate limit exceeded")
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	var response GraphQLResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return &response, nil
+	return nil
 }
 
 func parseOwnerRepo(name string) (string, string, error) {
@@ -332,8 +467,8 @@ func (c *Client) GetLatestVersion(name string) (string, error) {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	if c.token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("token %s", c.token))
+	if c.authToken() != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("token %s", c.authToken()))
 	}
 
 	resp, err := c.httpClient.Do(req)
@@ -358,7 +493,7 @@ func (c *Client) GetLatestVersion(name string) (string, error) {
 	}
 
 	if len(versions) == 0 {
-		return "", fmt.Errorf("no versions found for repository '%s'", name)
+		return "", fmt.Errorf("%w for repository '%s'", ErrNoVersions, name)
 	}
 
 	return versions[0].Version, nil
@@ -370,8 +505,8 @@ func (c *Client) fetchJSON(url string, v any) error {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	if c.token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("token %s", c.token))
+	if c.authToken() != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("token %s", c.authToken()))
 	}
 
 	resp, err := c.httpClient.Do(req)
@@ -380,10 +515,18 @@ func (c *Client) fetchJSON(url string, v any) error {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusForbidden && c.authToken() != "" && resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return fmt.Errorf("%s %w", strings.TrimPrefix(url, githubAPIURL), errForbidden)
+	}
+
 	if resp.StatusCode == http.StatusForbidden {
 		return fmt.Errorf("rate limit exceeded for unauthenticated requests. Set GITHUB_TOKEN environment variable to use GraphQL API with higher rate limits")
 	}
 
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s %w", strings.TrimPrefix(url, githubAPIURL), errNotFound)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
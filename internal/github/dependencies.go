@@ -0,0 +1,184 @@
+package github
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/acidghost/a555pq/internal/manifest"
+	"github.com/git-pkgs/purl"
+)
+
+// GetSBOM fetches the SPDX document GitHub's dependency graph produces for a
+// repository. The document is returned as-is so it can be written out
+// verbatim; use [SBOMDependencies] to inspect it.
+func (c *Client) GetSBOM(name string) (json.RawMessage, error) {
+	if _, _, err := parseOwnerRepo(name); err != nil {
+		return nil, err
+	}
+
+	var response SBOMResponse
+	url := fmt.Sprintf("%s/repos/%s/dependency-graph/sbom", githubAPIURL, name)
+	if err := c.fetchJSON(url, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch dependency graph SBOM: %w", err)
+	}
+	if len(response.SBOM) == 0 {
+		return nil, fmt.Errorf("empty dependency graph SBOM for '%s'", name)
+	}
+	return response.SBOM, nil
+}
+
+// SBOMDependencies returns the direct dependencies of the package an SPDX
+// document describes, i.e. the targets of its DEPENDS_ON relationships.
+// Packages without a purl (such as the repository itself) are skipped.
+func SBOMDependencies(doc json.RawMessage) ([]manifest.Dependency, error) {
+	var sbom SPDXDocument
+	if err := json.Unmarshal(doc, &sbom); err != nil {
+		return nil, fmt.Errorf("failed to decode SPDX document: %w", err)
+	}
+
+	roots := make(map[string]struct{})
+	for _, id := range sbom.DocumentDescribes {
+		roots[id] = struct{}{}
+	}
+	for _, rel := range sbom.Relationships {
+		if rel.RelationshipType == "DESCRIBES" && rel.SPDXElementID == sbom.SPDXID {
+			roots[rel.RelatedSPDXElement] = struct{}{}
+		}
+	}
+
+	direct := make(map[string]struct{})
+	for _, rel := range sbom.Relationships {
+		if _, ok := roots[rel.SPDXElementID]; ok && rel.RelationshipType == "DEPENDS_ON" {
+			direct[rel.RelatedSPDXElement] = struct{}{}
+		}
+	}
+
+	var deps []manifest.Dependency
+	for _, pkg := range sbom.Packages {
+		if _, ok := roots[pkg.SPDXID]; ok {
+			continue
+		}
+		// Documents without relationships list only direct dependencies.
+		if _, ok := direct[pkg.SPDXID]; !ok && len(direct) > 0 {
+			continue
+		}
+
+		p := pkg.purl()
+		if p == nil {
+			continue
+		}
+		deps = append(deps, manifest.Dependency{
+			Ecosystem:    p.Type,
+			Name:         p.FullName(),
+			Requirements: pkg.VersionInfo,
+			Scope:        manifest.Runtime,
+		})
+	}
+
+	SortDependencies(deps)
+	return deps, nil
+}
+
+// GetManifestDependencies detects the top-level manifests of a repository
+// through the contents API and parses their direct dependencies. It is the
+// fallback for repositories whose dependency graph is unavailable.
+func (c *Client) GetManifestDependencies(name string) ([]manifest.Dependency, error) {
+	if _, _, err := parseOwnerRepo(name); err != nil {
+		return nil, err
+	}
+
+	var entries []ContentEntry
+	url := fmt.Sprintf("%s/repos/%s/contents/", githubAPIURL, name)
+	if err := c.fetchJSON(url, &entries); err != nil {
+		return nil, fmt.Errorf("failed to list repository contents: %w", err)
+	}
+
+	var deps []manifest.Dependency
+	for _, entry := range entries {
+		if entry.Type != "file" || !manifest.IsSupported(entry.Name) || manifest.IsLockfile(entry.Name) {
+			continue
+		}
+
+		data, err := c.getFileContents(name, entry.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, err := manifest.Parse(entry.Name, data)
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range parsed {
+			if !dep.Indirect {
+				deps = append(deps, dep)
+			}
+		}
+	}
+
+	SortDependencies(deps)
+	return deps, nil
+}
+
+func (c *Client) getFileContents(name, path string) ([]byte, error) {
+	var file ContentEntry
+	url := fmt.Sprintf("%s/repos/%s/contents/%s", githubAPIURL, name, path)
+	if err := c.fetchJSON(url, &file); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", path, err)
+	}
+	if file.Encoding != "base64" {
+		return nil, fmt.Errorf("unsupported encoding %q for %s", file.Encoding, path)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// FilterDependencies keeps only the dependencies of the given ecosystems.
+// Ecosystem names are matched case-insensitively against purl types, with
+// common aliases such as "go" or "rubygems" normalised first. An empty list
+// keeps everything.
+func FilterDependencies(deps []manifest.Dependency, ecosystems []string) []manifest.Dependency {
+	if len(ecosystems) == 0 {
+		return deps
+	}
+
+	wanted := make(map[string]struct{})
+	for _, eco := range ecosystems {
+		wanted[purl.EcosystemToPURLType(eco)] = struct{}{}
+	}
+
+	var filtered []manifest.Dependency
+	for _, dep := range deps {
+		if _, ok := wanted[dep.Ecosystem]; ok {
+			filtered = append(filtered, dep)
+		}
+	}
+	return filtered
+}
+
+// SortDependencies orders dependencies by ecosystem, then by name.
+func SortDependencies(deps []manifest.Dependency) {
+	sort.SliceStable(deps, func(i, j int) bool {
+		if deps[i].Ecosystem != deps[j].Ecosystem {
+			return deps[i].Ecosystem < deps[j].Ecosystem
+		}
+		return deps[i].Name < deps[j].Name
+	})
+}
+
+func (p SPDXPackage) purl() *purl.PURL {
+	for _, ref := range p.ExternalRefs {
+		if ref.ReferenceType != "purl" {
+			continue
+		}
+		if parsed, err := purl.Parse(ref.ReferenceLocator); err == nil {
+			return parsed
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/acidghost/a555pq/internal/manifest"
+)
+
+const testSBOM = `{
+	"spdxVersion": "SPDX-2.3",
+	"SPDXID": "SPDXRef-DOCUMENT",
+	"name": "com.github.o/r",
+	"documentDescribes": ["SPDXRef-com.github.o-r"],
+	"packages": [
+		{"SPDXID": "SPDXRef-com.github.o-r", "name": "com.github.o/r", "versionInfo": "main",
+		 "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:github/o/r@main"}]},
+		{"SPDXID": "SPDXRef-npm-babel-core", "name": "npm:@babel/core", "versionInfo": "^7.24.0",
+		 "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:npm/%40babel/core@7.24.0"}]},
+		{"SPDXID": "SPDXRef-npm-semver", "name": "npm:semver", "versionInfo": "6.3.1",
+		 "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:npm/semver@6.3.1"}]},
+		{"SPDXID": "SPDXRef-go-cobra", "name": "go:github.com/spf13/cobra", "versionInfo": "v1.8.0",
+		 "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:golang/github.com/spf13/cobra@v1.8.0"}]}
+	],
+	"relationships": [
+		{"spdxElementId": "SPDXRef-DOCUMENT", "relatedSpdxElement": "SPDXRef-com.github.o-r", "relationshipType": "DESCRIBES"},
+		{"spdxElementId": "SPDXRef-com.github.o-r", "relatedSpdxElement": "SPDXRef-npm-babel-core", "relationshipType": "DEPENDS_ON"},
+		{"spdxElementId": "SPDXRef-com.github.o-r", "relatedSpdxElement": "SPDXRef-go-cobra", "relationshipType": "DEPENDS_ON"},
+		{"spdxElementId": "SPDXRef-npm-babel-core", "relatedSpdxElement": "SPDXRef-npm-semver", "relationshipType": "DEPENDS_ON"}
+	]
+}`
+
+func TestSBOMDependencies(t *testing.T) {
+	deps, err := SBOMDependencies([]byte(testSBOM))
+	if err != nil {
+		t.Fatalf("SBOMDependencies() error = %v", err)
+	}
+
+	want := []manifest.Dependency{
+		{Ecosystem: "golang", Name: "github.com/spf13/cobra", Requirements: "v1.8.0", Scope: manifest.Runtime},
+		{Ecosystem: "npm", Name: "@babel/core", Requirements: "^7.24.0", Scope: manifest.Runtime},
+	}
+	if !reflect.DeepEqual(deps, want) {
+		t.Errorf("SBOMDependencies() =\n%+v\nwant\n%+v", deps, want)
+	}
+
+	filtered := FilterDependencies(deps, []string{"go"})
+	if len(filtered) != 1 || filtered[0].Ecosystem != "golang" {
+		t.Errorf("FilterDependencies(go) = %+v", filtered)
+	}
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// OrgRepository is one repository of an organisation or user account, as
+// listed by GetOrgRepositories.
+type OrgRepository struct {
+	Name          string
+	Visibility    string
+	Archived      bool
+	Language      string
+	Topics        []string
+	DefaultBranch string
+	PushedAt      time.Time
+	LatestRelease string
+	ReleasedAt    time.Time
+}
+
+// OrgFilter narrows down the repositories GetOrgRepositories returns.
+type OrgFilter struct {
+	// Topics lists topics a repository must all have.
+	Topics []string
+	// Language is the primary language, compared case-insensitively.
+	Language        string
+	ExcludeArchived bool
+	// NoRelease keeps only repositories that have never published a release.
+	NoRelease bool
+}
+
+// Match reports whether repo passes the filter.
+func (f OrgFilter) Match(repo OrgRepository) bool {
+	if f.ExcludeArchived && repo.Archived {
+		return false
+	}
+	if f.NoRelease && repo.LatestRelease != "" {
+		return false
+	}
+	if f.Language != "" && !strings.EqualFold(f.Language, repo.Language) {
+		return false
+	}
+	for _, topic := range f.Topics {
+		if !slices.Contains(repo.Topics, strings.ToLower(topic)) {
+			return false
+		}
+	}
+	return true
+}
+
+// orgPageResponse is the response to orgRepositoriesQuery.
+type orgPageResponse struct {
+	Data struct {
+		RepositoryOwner *struct {
+			Repositories struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []orgRepositoryNode `json:"nodes"`
+			} `json:"repositories"`
+		} `json:"repositoryOwner"`
+	} `json:"data"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
+
+type orgRepositoryNode struct {
+	Name             string     `json:"name"`
+	Visibility       string     `json:"visibility"`
+	IsArchived       bool       `json:"isArchived"`
+	PushedAt         *time.Time `json:"pushedAt"`
+	DefaultBranchRef *struct {
+		Name string `json:"name"`
+	} `json:"defaultBranchRef"`
+	PrimaryLanguage *struct {
+		Name string `json:"name"`
+	} `json:"primaryLanguage"`
+	RepositoryTopics struct {
+		Nodes []struct {
+			Topic struct {
+				Name string `json:"name"`
+			} `json:"topic"`
+		} `json:"nodes"`
+	} `json:"repositoryTopics"`
+	LatestRelease *struct {
+		TagName     string     `json:"tagName"`
+		PublishedAt *time.Time `json:"publishedAt"`
+	} `json:"latestRelease"`
+}
+
+// repositoryOwner covers both organisations and users.
+const orgRepositoriesQuery = `
+	query($login: String!, $cursor: String) {
+		repositoryOwner(login: $login) {
+			repositories(first: 100, after: $cursor, orderBy: {field: NAME, direction: ASC}) {
+				pageInfo { hasNextPage endCursor }
+				nodes {
+					name
+					visibility
+					isArchived
+					pushedAt
+					defaultBranchRef { name }
+					primaryLanguage { name }
+					repositoryTopics(first: 100) { nodes { topic { name } } }
+					latestRelease { tagName publishedAt }
+				}
+			}
+		}
+	}
+`
+
+// GetOrgRepositories lists the repositories of an organisation or user
+// account that pass filter, ordered by name. The listing is paginated over
+// GraphQL, so a token is required.
+func (c *Client) GetOrgRepositories(owner string, filter OrgFilter) ([]OrgRepository, error) {
+	if c.authToken() == "" || c.forceREST {
+		return nil, fmt.Errorf("listing repositories requires the GraphQL API, set GITHUB_TOKEN environment variable")
+	}
+
+	var (
+		repos  []OrgRepository
+		cursor *string
+	)
+	for {
+		var response orgPageResponse
+		variables := map[string]any{"login": owner, "cursor": cursor}
+		if err := c.executeGraphQL(orgRepositoriesQuery, variables, &response); err != nil {
+			return nil, err
+		}
+		if len(response.Errors) > 0 {
+			return nil, fmt.Errorf("GraphQL error: %v", response.Errors[0].Message)
+		}
+
+		repoOwner := response.Data.RepositoryOwner
+		if repoOwner == nil {
+			return nil, fmt.Errorf("organisation or user '%s' not found", owner)
+		}
+
+		for _, node := range repoOwner.Repositories.Nodes {
+			if repo := node.repository(); filter.Match(repo) {
+				repos = append(repos, repo)
+			}
+		}
+
+		page := repoOwner.Repositories.PageInfo
+		if !page.HasNextPage {
+			return repos, nil
+		}
+		cursor = &page.EndCursor
+	}
+}
+
+func (n orgRepositoryNode) repository() OrgRepository {
+	repo := OrgRepository{
+		Name:       n.Name,
+		Visibility: strings.ToLower(n.Visibility),
+		Archived:   n.IsArchived,
+	}
+	if n.PushedAt != nil {
+		repo.PushedAt = *n.PushedAt
+	}
+	if n.DefaultBranchRef != nil {
+		repo.DefaultBranch = n.DefaultBranchRef.Name
+	}
+	if n.PrimaryLanguage != nil {
+		repo.Language = n.PrimaryLanguage.Name
+	}
+	for _, topic := range n.RepositoryTopics.Nodes {
+		repo.Topics = append(repo.Topics, topic.Topic.Name)
+	}
+	if n.LatestRelease != nil {
+		repo.LatestRelease = n.LatestRelease.TagName
+		if n.LatestRelease.PublishedAt != nil {
+			repo.ReleasedAt = *n.LatestRelease.PublishedAt
+		}
+	}
+	return repo
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestOrgRepositoriesDecoding(t *testing.T) {
+	body := `{
+		"data": {
+			"repositoryOwner": {
+				"repositories": {
+					"pageInfo": {"hasNextPage": true, "endCursor": "Y3Vyc29y"},
+					"nodes": [
+						{
+							"name": "api",
+							"visibility": "INTERNAL",
+							"isArchived": false,
+							"pushedAt": "2024-05-01T10:00:00Z",
+							"defaultBranchRef": {"name": "main"},
+							"primaryLanguage": {"name": "Go"},
+							"repositoryTopics": {"nodes": [{"topic": {"name": "service"}}, {"topic": {"name": "payments"}}]},
+							"latestRelease": {"tagName": "v1.2.0", "publishedAt": "2024-04-01T00:00:00Z"}
+						},
+						{
+							"name": "legacy",
+							"visibility": "PRIVATE",
+							"isArchived": true,
+							"pushedAt": null,
+							"defaultBranchRef": null,
+							"primaryLanguage": null,
+							"repositoryTopics": {"nodes": []},
+							"latestRelease": null
+						}
+					]
+				}
+			}
+		}
+	}`
+
+	var response orgPageResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	repos := response.Data.RepositoryOwner.Repositories
+	if !repos.PageInfo.HasNextPage || repos.PageInfo.EndCursor != "Y3Vyc29y" {
+		t.Errorf("unexpected page info: %+v", repos.PageInfo)
+	}
+
+	api := repos.Nodes[0].repository()
+	if api.Visibility != "internal" || api.DefaultBranch != "main" || api.Language != "Go" ||
+		api.LatestRelease != "v1.2.0" || !api.ReleasedAt.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)) ||
+		len(api.Topics) != 2 {
+		t.Errorf("unexpected repository: %+v", api)
+	}
+
+	legacy := repos.Nodes[1].repository()
+	if !legacy.Archived || !legacy.PushedAt.IsZero() || legacy.LatestRelease != "" || legacy.DefaultBranch != "" {
+		t.Errorf("unexpected repository: %+v", legacy)
+	}
+}
+
+func TestOrgFilterMatch(t *testing.T) {
+	api := OrgRepository{Name: "api", Language: "Go", Topics: []string{"service", "payments"}, LatestRelease: "v1.2.0"}
+	legacy := OrgRepository{Name: "legacy", Archived: true}
+
+	tests := []struct {
+		name   string
+		filter OrgFilter
+		repo   OrgRepository
+		want   bool
+	}{
+		{"no filter", OrgFilter{}, legacy, true},
+		{"exclude archived", OrgFilter{ExcludeArchived: true}, legacy, false},
+		{"no release keeps unreleased", OrgFilter{NoRelease: true}, legacy, true},
+		{"no release drops released", OrgFilter{NoRelease: true}, api, false},
+		{"language case-insensitive", OrgFilter{Language: "go"}, api, true},
+		{"language mismatch", OrgFilter{Language: "rust"}, api, false},
+		{"all topics", OrgFilter{Topics: []string{"Service", "payments"}}, api, true},
+		{"missing topic", OrgFilter{Topics: []string{"service", "frontend"}}, api, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.repo); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
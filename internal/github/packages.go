@@ -0,0 +1,169 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// PackageTypes are the package types of the GitHub Packages API.
+var PackageTypes = []string{"npm", "maven", "rubygems", "docker", "nuget", "container"}
+
+// maxPackagePages bounds how many pages of packages or versions are listed.
+const maxPackagePages = 10
+
+// maxConcurrentPackages bounds how many packages GetLatestPackageVersions
+// queries at once.
+const maxConcurrentPackages = 8
+
+// ParsePackagePath splits "<owner>/<type>/<name>" into its parts. Names may
+// contain slashes, as container images often do.
+func ParsePackagePath(path string) (owner, packageType, name string, err error) {
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("invalid package format: expected 'owner/type/name', got '%s'", path)
+	}
+	if !slices.Contains(PackageTypes, parts[1]) {
+		return "", "", "", fmt.Errorf("invalid package type '%s', expected one of %s", parts[1], strings.Join(PackageTypes, ", "))
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// ownerBase returns the API path of owner's packages, trying the
+// organisation endpoint first and falling back to the user one.
+func (c *Client) ownerBase(owner, packageType string) (string, error) {
+	orgBase := fmt.Sprintf("%s/orgs/%s/packages", githubAPIURL, owner)
+	var probe []Package
+	err := c.fetchJSON(fmt.Sprintf("%s?package_type=%s&per_page=1", orgBase, packageType), &probe)
+	if err == nil {
+		return orgBase, nil
+	}
+	if !errors.Is(err, errNotFound) {
+		return "", packagesError(owner, err)
+	}
+	return fmt.Sprintf("%s/users/%s/packages", githubAPIURL, owner), nil
+}
+
+// GetPackages lists the packages of an organisation or user. The API
+// requires a package type, so all types are listed when packageType is
+// empty. Reading packages requires a token with the read:packages scope.
+func (c *Client) GetPackages(owner, packageType string) ([]Package, error) {
+	types := PackageTypes
+	if packageType != "" {
+		if !slices.Contains(PackageTypes, packageType) {
+			return nil, fmt.Errorf("invalid package type '%s', expected one of %s", packageType, strings.Join(PackageTypes, ", "))
+		}
+		types = []string{packageType}
+	}
+
+	base, err := c.ownerBase(owner, types[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch packages: %w", err)
+	}
+
+	var packages []Package
+	for _, t := range types {
+		for page := 1; page <= maxPackagePages; page++ {
+			var batch []Package
+			err := c.fetchJSON(fmt.Sprintf("%s?package_type=%s&per_page=100&page=%d", base, t, page), &batch)
+			if errors.Is(err, errNotFound) {
+				return nil, fmt.Errorf("owner '%s' not found", owner)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch %s packages: %w", t, packagesError(owner, err))
+			}
+			packages = append(packages, batch...)
+			if len(batch) < 100 {
+				break
+			}
+		}
+	}
+	return packages, nil
+}
+
+// GetPackage returns a single package of an organisation or user.
+func (c *Client) GetPackage(owner, packageType, name string) (*Package, error) {
+	base, err := c.ownerBase(owner, packageType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch package: %w", err)
+	}
+
+	var pkg Package
+	err = c.fetchJSON(fmt.Sprintf("%s/%s/%s", base, packageType, url.PathEscape(name)), &pkg)
+	if errors.Is(err, errNotFound) {
+		return nil, fmt.Errorf("package '%s/%s/%s' not found", owner, packageType, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch package: %w", packagesError(owner, err))
+	}
+	return &pkg, nil
+}
+
+// GetPackageVersions lists the versions of pkg, newest first. With
+// limit > 0, at most limit versions are returned.
+func (c *Client) GetPackageVersions(pkg Package, limit int) ([]PackageVersion, error) {
+	perPage := 100
+	if limit > 0 && limit < perPage {
+		perPage = limit
+	}
+
+	var versions []PackageVersion
+	for page := 1; page <= maxPackagePages; page++ {
+		var batch []PackageVersion
+		if err := c.fetchJSON(fmt.Sprintf("%s/versions?per_page=%d&page=%d", pkg.URL, perPage, page), &batch); err != nil {
+			return nil, fmt.Errorf("failed to fetch versions of package '%s': %w", pkg.Name, packagesError(pkg.Owner.Login, err))
+		}
+		versions = append(versions, batch...)
+		if len(batch) < perPage || (limit > 0 && len(versions) >= limit) {
+			break
+		}
+	}
+	if limit > 0 && len(versions) > limit {
+		versions = versions[:limit]
+	}
+	return versions, nil
+}
+
+// LatestPackageVersion is the outcome of a latest-version lookup for one
+// package. Version is nil when the package has no versions or Err is set.
+type LatestPackageVersion struct {
+	Version *PackageVersion
+	Err     error
+}
+
+// GetLatestPackageVersions returns the latest version of every package,
+// querying up to maxConcurrentPackages packages at once. Results are in the
+// order of packages.
+func (c *Client) GetLatestPackageVersions(packages []Package) []LatestPackageVersion {
+	results := make([]LatestPackageVersion, len(packages))
+	sem := make(chan struct{}, maxConcurrentPackages)
+	var wg sync.WaitGroup
+	for i, pkg := range packages {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			versions, err := c.GetPackageVersions(pkg, 1)
+			results[i].Err = err
+			if len(versions) > 0 {
+				results[i].Version = &versions[0]
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// packagesError explains a 403 from the packages API, which GitHub returns
+// when the token lacks the read:packages scope.
+func packagesError(owner string, err error) error {
+	if errors.Is(err, errForbidden) {
+		return fmt.Errorf("access to the packages of '%s' denied: the token lacks the read:packages scope", owner)
+	}
+	return err
+}
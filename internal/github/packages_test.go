@@ -0,0 +1,66 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePackagePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		wantName string
+		wantErr  bool
+	}{
+		{"acme/npm/widgets", "widgets", false},
+		{"acme/container/tools/builder", "tools/builder", false},
+		{"acme/pypi/widgets", "", true},
+		{"acme/npm", "", true},
+		{"/npm/widgets", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			owner, _, name, err := ParsePackagePath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePackagePath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (owner != "acme" || name != tt.wantName) {
+				t.Errorf("ParsePackagePath() = %q, %q, want acme, %q", owner, name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestPackageVersionTags(t *testing.T) {
+	body := `[
+		{"id": 2, "name": "sha256:abc", "created_at": "2024-05-01T00:00:00Z",
+		 "metadata": {"package_type": "container", "container": {"tags": ["latest", "1.2.0"]}}},
+		{"id": 1, "name": "1.1.0", "created_at": "2024-04-01T00:00:00Z",
+		 "metadata": {"package_type": "npm"}}
+	]`
+
+	var versions []PackageVersion
+	if err := json.Unmarshal([]byte(body), &versions); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got := versions[0].Tags(); !reflect.DeepEqual(got, []string{"latest", "1.2.0"}) {
+		t.Errorf("Tags() = %v", got)
+	}
+	if got := versions[1].Tags(); got != nil {
+		t.Errorf("Tags() = %v, want nil for npm versions", got)
+	}
+}
+
+func TestPackagesError(t *testing.T) {
+	err := packagesError("acme", fmt.Errorf("/orgs/acme/packages %w", errForbidden))
+	if err == nil || !strings.Contains(err.Error(), "read:packages scope") {
+		t.Errorf("packagesError() = %v, want a missing scope error", err)
+	}
+
+	other := fmt.Errorf("/orgs/acme/packages %w", errNotFound)
+	if got := packagesError("acme", other); got != other {
+		t.Errorf("packagesError() = %v, want %v unchanged", got, other)
+	}
+}
@@ -0,0 +1,82 @@
+package github
+
+import (
+	"strings"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+)
+
+// Verification statuses reported for versions. Invalid signatures are
+// reported as "invalid: <reason>", the reason being GitHub's signature state.
+const (
+	verificationValid    = "valid"
+	verificationUnsigned = "unsigned"
+	verificationInvalid  = "invalid"
+)
+
+// verification describes a signature as shown in the verification column.
+func verification(sig *GraphQLSignature) string {
+	switch {
+	case sig == nil || sig.State == "UNSIGNED":
+		return verificationUnsigned
+	case sig.IsValid:
+		if sig.Signer != nil && sig.Signer.Login != "" {
+			return verificationValid + " (" + sig.Signer.Login + ")"
+		}
+		return verificationValid
+	default:
+		reason := strings.ToLower(strings.ReplaceAll(sig.State, "_", " "))
+		if reason == "" {
+			return verificationInvalid
+		}
+		return verificationInvalid + ": " + reason
+	}
+}
+
+func isVerified(status string) bool {
+	return status == verificationValid || strings.HasPrefix(status, verificationValid+" (")
+}
+
+// refSignature returns the signature that vouches for a tag: the tag object
+// signature for annotated tags and the commit signature for lightweight ones.
+func refSignature(target *GraphQLRefTarget) *GraphQLSignature {
+	if target == nil {
+		return nil
+	}
+	return target.Signature
+}
+
+// releaseSignature is like refSignature for the tag of a release, falling
+// back to the signature of the release commit when the tag is lightweight.
+func releaseSignature(release GraphQLReleaseNode) *GraphQLSignature {
+	if release.Tag != nil && release.Tag.Target != nil && release.Tag.Target.Typename == "Tag" {
+		return release.Tag.Target.Signature
+	}
+	return refSignature(release.TagCommit)
+}
+
+// addVerification fills in the verification status of versions, as returned
+// by repositoryVersions for repo.
+func addVerification(repo *GraphQLRepository, versions []formatter.VersionItem) {
+	statuses := make(map[string]string)
+	if repo.Releases != nil {
+		for _, release := range repo.Releases.Nodes {
+			statuses[release.TagName] = verification(releaseSignature(release))
+		}
+	}
+	if repo.Refs != nil {
+		for _, ref := range repo.Refs.Nodes {
+			if _, ok := statuses[ref.Name]; !ok {
+				statuses[ref.Name] = verification(refSignature(ref.Target))
+			}
+		}
+	}
+
+	for i := range versions {
+		if status, ok := statuses[versions[i].Version]; ok {
+			versions[i].Verification = status
+		} else {
+			versions[i].Verification = verificationUnsigned
+		}
+	}
+}
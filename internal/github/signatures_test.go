@@ -0,0 +1,69 @@
+package github
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAddVerification(t *testing.T) {
+	body := `{
+		"data": {
+			"repository": {
+				"releases": {"nodes": [
+					{
+						"tagName": "v2.0.0", "isPrerelease": false, "publishedAt": "2024-03-01T00:00:00Z",
+						"tag": {"target": {"__typename": "Tag", "signature": {"isValid": true, "state": "VALID", "signer": {"login": "jane"}}}},
+						"tagCommit": {"signature": null}
+					},
+					{
+						"tagName": "v1.9.0", "isPrerelease": false, "publishedAt": "2024-02-01T00:00:00Z",
+						"tag": {"target": {"__typename": "Commit"}},
+						"tagCommit": {"signature": {"isValid": true, "state": "VALID", "signer": null}}
+					},
+					{
+						"tagName": "v1.8.0", "isPrerelease": false, "publishedAt": "2024-01-01T00:00:00Z",
+						"tag": {"target": {"__typename": "Tag", "signature": null}},
+						"tagCommit": {"signature": {"isValid": true, "state": "VALID"}}
+					}
+				]},
+				"refs": {"nodes": [
+					{"name": "v2.0.0", "target": {"__typename": "Tag"}},
+					{"name": "v1.7.0", "target": {"__typename": "Tag", "signature": {"isValid": false, "state": "UNKNOWN_KEY"}}},
+					{"name": "v1.6.0", "target": {"__typename": "Commit", "signature": {"isValid": false, "state": "UNSIGNED"}}}
+				]}
+			}
+		}
+	}`
+
+	var response GraphQLResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	repo := response.Data["repository"]
+	versions := repositoryVersions(repo)
+	addVerification(repo, versions)
+
+	want := map[string]string{
+		"v2.0.0": "valid (jane)",
+		"v1.9.0": "valid",
+		"v1.8.0": "unsigned",
+		"v1.7.0": "invalid: unknown key",
+		"v1.6.0": "unsigned",
+	}
+	if len(versions) != len(want) {
+		t.Fatalf("got %d versions, want %d: %+v", len(versions), len(want), versions)
+	}
+	for _, v := range versions {
+		if v.Verification != want[v.Version] {
+			t.Errorf("%s: verification = %q, want %q", v.Version, v.Verification, want[v.Version])
+		}
+	}
+
+	for status, verified := range map[string]bool{
+		"valid": true, "valid (jane)": true, "unsigned": false, "invalid: unknown key": false, "validity": false,
+	} {
+		if isVerified(status) != verified {
+			t.Errorf("isVerified(%q) = %v, want %v", status, !verified, verified)
+		}
+	}
+}
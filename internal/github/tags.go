@@ -0,0 +1,220 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/acidghost/a555pq/internal/formatter"
+)
+
+// componentPattern splits monorepo tags such as "cli/v1.4.0",
+// "@scope/pkg@2.1.0" or "sdk-go-v0.9.1" into a component and a version. Plain
+// version tags belong to the root component, named "".
+var componentPattern = regexp.MustCompile(`^(?P<component>.*?)[/@_-]?v?(?P<version>\d+(?:\.\d+)+(?:[-+][0-9A-Za-z.+-]*)?)$`)
+
+// TagMatcher selects the tags of one component of a monorepo and extracts
+// the version from them, either by stripping a prefix or with a regular
+// expression. A nil TagMatcher matches every tag as is.
+type TagMatcher struct {
+	prefix         string
+	pattern        *regexp.Regexp
+	versionGroup   int
+	componentGroup int
+}
+
+// NewTagMatcher returns a matcher for tags starting with prefix, or matching
+// pattern. The pattern must have a capture group for the version: the group
+// named "version" if there is one, the first group otherwise. A group named
+// "component" is used to tell components apart when listing them. It returns
+// nil when both are empty.
+func NewTagMatcher(prefix, pattern string) (*TagMatcher, error) {
+	if prefix == "" && pattern == "" {
+		return nil, nil
+	}
+
+	m := &TagMatcher{prefix: prefix}
+	if pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag pattern: %w", err)
+		}
+		if re.NumSubexp() == 0 {
+			return nil, fmt.Errorf("tag pattern %q has no capture group for the version", pattern)
+		}
+		m.pattern = re
+		m.versionGroup = 1
+		if i := re.SubexpIndex("version"); i > 0 {
+			m.versionGroup = i
+		}
+		if i := re.SubexpIndex("component"); i > 0 {
+			m.componentGroup = i
+		}
+	}
+	return m, nil
+}
+
+// Match reports whether tag is selected by the matcher and returns the
+// version part of it.
+func (m *TagMatcher) Match(tag string) (string, bool) {
+	if m == nil {
+		return tag, true
+	}
+	if !strings.HasPrefix(tag, m.prefix) {
+		return "", false
+	}
+	if m.pattern == nil {
+		return strings.TrimPrefix(tag, m.prefix), true
+	}
+	sub := m.pattern.FindStringSubmatch(tag)
+	if sub == nil || sub[m.versionGroup] == "" {
+		return "", false
+	}
+	return sub[m.versionGroup], true
+}
+
+// Version returns the semantic version extracted from tag, or nil when the
+// tag does not match or its version part is not a semantic version.
+func (m *TagMatcher) Version(tag string) *semver.Version {
+	version, ok := m.Match(tag)
+	if !ok {
+		return nil
+	}
+	v, err := semver.NewVersion(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// Filter keeps the versions whose tag matches and orders them by descending
+// extracted version. Tags whose version part is not a semantic version sort
+// last, in their original order.
+func (m *TagMatcher) Filter(versions []formatter.VersionItem) []formatter.VersionItem {
+	if m == nil {
+		return versions
+	}
+
+	type match struct {
+		item    formatter.VersionItem
+		version *semver.Version
+	}
+	var matches []match
+	for _, item := range versions {
+		if _, ok := m.Match(item.Version); ok {
+			matches = append(matches, match{item, m.Version(item.Version)})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		vi, vj := matches[i].version, matches[j].version
+		switch {
+		case vi != nil && vj != nil:
+			return vj.LessThan(vi)
+		default:
+			return vi != nil && vj == nil
+		}
+	})
+
+	filtered := make([]formatter.VersionItem, len(matches))
+	for i, match := range matches {
+		filtered[i] = match.item
+	}
+	return filtered
+}
+
+// Component splits tag into the component it belongs to and its version.
+// Patterns without a "component" group put every matching tag in the root
+// component; otherwise tags are split with a built-in pattern that
+// recognises the usual monorepo conventions.
+func (m *TagMatcher) Component(tag string) (component, version string, ok bool) {
+	if m != nil && m.pattern != nil {
+		sub := m.pattern.FindStringSubmatch(tag)
+		if sub == nil || sub[m.versionGroup] == "" {
+			return "", "", false
+		}
+		if m.componentGroup > 0 {
+			component = sub[m.componentGroup]
+		}
+		return component, sub[m.versionGroup], true
+	}
+	if m != nil && !strings.HasPrefix(tag, m.prefix) {
+		return "", "", false
+	}
+
+	sub := componentPattern.FindStringSubmatch(tag)
+	if sub == nil {
+		return "", "", false
+	}
+	return sub[componentPattern.SubexpIndex("component")], sub[componentPattern.SubexpIndex("version")], true
+}
+
+// Component is the latest release of one component of a monorepo.
+type Component struct {
+	Name    string
+	Tag     string
+	Version string
+	Date    string
+}
+
+// GetComponents groups the tags of a repository by component, as split by
+// [TagMatcher.Component], and returns the latest version of each, sorted by
+// component name. Stable versions are preferred over prereleases.
+func (c *Client) GetComponents(name string, m *TagMatcher) ([]Component, error) {
+	if m == nil {
+		// ListVersions only lists every tag when filtering, so match them all.
+		m = &TagMatcher{}
+	}
+	versions, err := c.ListVersions(name, VersionsOptions{Tags: m})
+	if err != nil {
+		return nil, err
+	}
+	return latestComponents(versions, m), nil
+}
+
+func latestComponents(versions []formatter.VersionItem, m *TagMatcher) []Component {
+	type candidate struct {
+		Component
+		version *semver.Version
+	}
+	best := make(map[string]candidate)
+	for _, item := range versions {
+		component, version, ok := m.Component(item.Version)
+		if !ok {
+			continue
+		}
+		v, err := semver.NewVersion(strings.TrimPrefix(version, "v"))
+		if err != nil {
+			continue
+		}
+		current, seen := best[component]
+		if seen && !newerComponentVersion(v, current.version) {
+			continue
+		}
+		best[component] = candidate{
+			Component: Component{Name: component, Tag: item.Version, Version: version, Date: item.UploadDate},
+			version:   v,
+		}
+	}
+
+	components := make([]Component, 0, len(best))
+	for _, candidate := range best {
+		components = append(components, candidate.Component)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Name < components[j].Name
+	})
+	return components
+}
+
+// newerComponentVersion reports whether v should replace current as the
+// latest version of a component.
+func newerComponentVersion(v, current *semver.Version) bool {
+	stable, currentStable := v.Prerelease() == "", current.Prerelease() == ""
+	if stable != currentStable {
+		return stable
+	}
+	return v.GreaterThan(current)
+}
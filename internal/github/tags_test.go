@@ -0,0 +1,105 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+)
+
+func TestTagMatcherMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		prefix      string
+		pattern     string
+		tag         string
+		wantVersion string
+		wantOK      bool
+	}{
+		{"prefix", "cli/", "", "cli/v1.4.0", "v1.4.0", true},
+		{"prefix mismatch", "cli/", "", "sdk/v1.4.0", "", false},
+		{"scoped package prefix", "@scope/pkg@", "", "@scope/pkg@2.1.0", "2.1.0", true},
+		{"pattern first group", "", `^sdk-go-v(.+)$`, "sdk-go-v0.9.1", "0.9.1", true},
+		{"pattern named group", "", `^(sdk|cli)-(?P<version>[\d.]+)$`, "cli-1.2.3", "1.2.3", true},
+		{"pattern mismatch", "", `^sdk-go-v(.+)$`, "sdk-py-v0.9.1", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewTagMatcher(tt.prefix, tt.pattern)
+			if err != nil {
+				t.Fatalf("NewTagMatcher() error = %v", err)
+			}
+			version, ok := m.Match(tt.tag)
+			if version != tt.wantVersion || ok != tt.wantOK {
+				t.Errorf("Match(%q) = %q, %v, want %q, %v", tt.tag, version, ok, tt.wantVersion, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNewTagMatcherErrors(t *testing.T) {
+	if m, err := NewTagMatcher("", ""); m != nil || err != nil {
+		t.Errorf("NewTagMatcher(\"\", \"\") = %v, %v, want nil, nil", m, err)
+	}
+	if _, err := NewTagMatcher("", `^v[\d.]+$`); err == nil {
+		t.Error("expected error for a pattern without capture group")
+	}
+	if _, err := NewTagMatcher("", `^v(`); err == nil {
+		t.Error("expected error for an invalid pattern")
+	}
+}
+
+func TestTagMatcherFilter(t *testing.T) {
+	m, _ := NewTagMatcher("cli/", "")
+	versions := []formatter.VersionItem{
+		{Version: "cli/v1.10.0"},
+		{Version: "sdk/v3.0.0"},
+		{Version: "cli/nightly"},
+		{Version: "cli/v1.9.0", UploadDate: "2024-01-01T00:00:00Z"},
+		{Version: "cli/v2.0.0-rc.1"},
+	}
+
+	got := m.Filter(versions)
+	var tags []string
+	for _, v := range got {
+		tags = append(tags, v.Version)
+	}
+	want := []string{"cli/v2.0.0-rc.1", "cli/v1.10.0", "cli/v1.9.0", "cli/nightly"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("Filter() = %v, want %v", tags, want)
+	}
+	if got[2].UploadDate != "2024-01-01T00:00:00Z" {
+		t.Errorf("Filter() dropped the upload date: %+v", got[2])
+	}
+}
+
+func TestLatestComponents(t *testing.T) {
+	var versions []formatter.VersionItem
+	for _, tag := range []string{
+		"cli/v1.4.0", "cli/v1.5.0-rc.1", "cli/v1.3.9",
+		"@scope/pkg@2.1.0", "@scope/pkg@2.0.0",
+		"sdk-go-v0.9.1", "sdk-go-v0.10.0",
+		"v3.0.0", "nightly",
+	} {
+		versions = append(versions, formatter.VersionItem{Version: tag})
+	}
+
+	got := latestComponents(versions, &TagMatcher{})
+	want := []Component{
+		{Name: "", Tag: "v3.0.0", Version: "3.0.0"},
+		{Name: "@scope/pkg", Tag: "@scope/pkg@2.1.0", Version: "2.1.0"},
+		{Name: "cli", Tag: "cli/v1.4.0", Version: "1.4.0"},
+		{Name: "sdk-go", Tag: "sdk-go-v0.10.0", Version: "0.10.0"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("latestComponents() = %+v, want %+v", got, want)
+	}
+
+	m, _ := NewTagMatcher("", `^(?P<component>[a-z-]+)-v(?P<version>.+)$`)
+	got = latestComponents(versions, m)
+	want = []Component{{Name: "sdk-go", Tag: "sdk-go-v0.10.0", Version: "0.10.0"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("latestComponents() with pattern = %+v, want %+v", got, want)
+	}
+}
@@ -1,6 +1,9 @@
 package github
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Repository struct {
 	ID               int64     `json:"id"`
@@ -106,29 +109,32 @@ type TagCommit struct {
 	Date string `json:"date"`
 }
 
+// GraphQLResponse is the envelope of a GraphQL API response. Data is keyed by
+// the top-level field name, which is "repository" for single-repository
+// queries and the alias (e.g. "r0") for batched queries.
 type GraphQLResponse struct {
-	Data   *GraphQLData   `json:"data"`
-	Errors []GraphQLError `json:"errors,omitempty"`
-}
-
-type GraphQLData struct {
-	Repository *GraphQLRepository `json:"repository"`
+	Data   map[string]*GraphQLRepository `json:"data"`
+	Errors []GraphQLError                `json:"errors,omitempty"`
 }
 
 type GraphQLRepository struct {
-	Releases *GraphQLConnection `json:"releases"`
-	Refs     *GraphQLConnection `json:"refs"`
+	NameWithOwner string                                 `json:"nameWithOwner"`
+	LatestRelease *GraphQLReleaseNode                    `json:"latestRelease"`
+	Releases      *GraphQLConnection[GraphQLReleaseNode] `json:"releases"`
+	Refs          *GraphQLConnection[GraphQLRefNode]     `json:"refs"`
 }
 
-type GraphQLConnection struct {
-	Nodes []any `json:"nodes"`
+type GraphQLConnection[T any] struct {
+	Nodes []T `json:"nodes"`
 }
 
 type GraphQLReleaseNode struct {
-	TagName      string `json:"tagName"`
-	IsPrerelease bool   `json:"isPrerelease"`
-	CreatedAt    string `json:"createdAt"`
-	PublishedAt  string `json:"publishedAt"`
+	TagName      string            `json:"tagName"`
+	IsPrerelease bool              `json:"isPrerelease"`
+	CreatedAt    string            `json:"createdAt"`
+	PublishedAt  string            `json:"publishedAt"`
+	Tag          *GraphQLRefNode   `json:"tag"`
+	TagCommit    *GraphQLRefTarget `json:"tagCommit"`
 }
 
 type GraphQLRefNode struct {
@@ -136,11 +142,42 @@ type GraphQLRefNode struct {
 	Target *GraphQLRefTarget `json:"target"`
 }
 
+// Date returns the most relevant date for the ref: the tagger date for
+// annotated tags, falling back to the commit author and committer dates.
+func (n GraphQLRefNode) Date() string {
+	t := n.Target
+	if t == nil {
+		return ""
+	}
+	if t.Tagger != nil && t.Tagger.Date != "" {
+		return t.Tagger.Date
+	}
+	if t.Author != nil && t.Author.Date != "" {
+		return t.Author.Date
+	}
+	if t.Committer != nil && t.Committer.Date != "" {
+		return t.Committer.Date
+	}
+	return ""
+}
+
 type GraphQLRefTarget struct {
+	Typename  string               `json:"__typename"`
 	Author    *GraphQLCommitAuthor `json:"author"`
 	Committer *GraphQLCommitAuthor `json:"committer"`
 	Tagger    *GraphQLTagger       `json:"tagger"`
 	Target    *GraphQLRefTarget    `json:"target"`
+	Signature *GraphQLSignature    `json:"signature"`
+}
+
+// GraphQLSignature is the GitSignature of a tag or commit object. It is nil
+// for objects that are not signed.
+type GraphQLSignature struct {
+	IsValid bool   `json:"isValid"`
+	State   string `json:"state"`
+	Signer  *struct {
+		Login string `json:"login"`
+	} `json:"signer"`
 }
 
 type GraphQLCommitAuthor struct {
@@ -151,8 +188,117 @@ type GraphQLTagger struct {
 	Date string `json:"date"`
 }
 
+// GraphQLError is a single entry of the errors array. Path elements are field
+// names or aliases (strings) and list indices (numbers).
 type GraphQLError struct {
-	Message string   `json:"message"`
-	Type    string   `json:"type"`
-	Path    []string `json:"path"`
+	Message string `json:"message"`
+	Type    string `json:"type"`
+	Path    []any  `json:"path"`
+}
+
+// Alias returns the top-level field name or alias the error refers to, or an
+// empty string for errors that are not tied to a field.
+func (e GraphQLError) Alias() string {
+	if len(e.Path) == 0 {
+		return ""
+	}
+	alias, _ := e.Path[0].(string)
+	return alias
+}
+
+type SBOMResponse struct {
+	SBOM json.RawMessage `json:"sbom"`
+}
+
+// SPDXDocument is the subset of an SPDX 2.3 JSON document needed to find a
+// package's direct dependencies.
+type SPDXDocument struct {
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentDescribes []string           `json:"documentDescribes"`
+	Packages          []SPDXPackage      `json:"packages"`
+	Relationships     []SPDXRelationship `json:"relationships"`
+}
+
+type SPDXPackage struct {
+	SPDXID       string            `json:"SPDXID"`
+	Name         string            `json:"name"`
+	VersionInfo  string            `json:"versionInfo"`
+	ExternalRefs []SPDXExternalRef `json:"externalRefs"`
+}
+
+type SPDXExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type SPDXRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+	RelationshipType   string `json:"relationshipType"`
+}
+
+type ContentEntry struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Type     string `json:"type"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+type GitRef struct {
+	Ref    string    `json:"ref"`
+	Object GitObject `json:"object"`
+}
+
+type GitTag struct {
+	Tag    string    `json:"tag"`
+	SHA    string    `json:"sha"`
+	Object GitObject `json:"object"`
+}
+
+type GitObject struct {
+	SHA  string `json:"sha"`
+	Type string `json:"type"`
+}
+
+// Package is a GitHub Packages package.
+type Package struct {
+	ID           int64       `json:"id"`
+	Name         string      `json:"name"`
+	PackageType  string      `json:"package_type"`
+	Owner        Owner       `json:"owner"`
+	VersionCount int         `json:"version_count"`
+	Visibility   string      `json:"visibility"`
+	URL          string      `json:"url"`
+	HTMLURL      string      `json:"html_url"`
+	Repository   *Repository `json:"repository"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+type PackageVersion struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	URL         string    `json:"url"`
+	HTMLURL     string    `json:"html_url"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Metadata    struct {
+		PackageType string `json:"package_type"`
+		Container   *struct {
+			Tags []string `json:"tags"`
+		} `json:"container"`
+	} `json:"metadata"`
+}
+
+// Tags returns the tags of a container image version; other package types
+// have none.
+func (v PackageVersion) Tags() []string {
+	if v.Metadata.Container == nil {
+		return nil
+	}
+	return v.Metadata.Container.Tags
 }
@@ -0,0 +1,232 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+)
+
+// DefaultHost is the GitLab instance queried when no host is configured.
+const DefaultHost = "gitlab.com"
+
+type Client struct {
+	httpClient *http.Client
+	host       string
+	baseURL    string
+
+	// The token is resolved on first use, so that commands which never
+	// call the API do not run glab.
+	tokenOnce sync.Once
+	token     string
+}
+
+// getGitLabToken looks for a token in GITLAB_TOKEN, then asks the glab CLI
+// for the token it stores for host. Like glab, GITLAB_TOKEN only applies to
+// gitlab.com, or to the instance named by GITLAB_HOST.
+func getGitLabToken(host string) string {
+	if envTokenApplies(host) {
+		if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+			return token
+		}
+	}
+
+	//nolint:gosec
+	cmd := exec.Command("glab", "config", "get", "token", "--host", host)
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// envTokenApplies reports whether GITLAB_TOKEN may be sent to host.
+func envTokenApplies(host string) bool {
+	if envHost := os.Getenv("GITLAB_HOST"); envHost != "" {
+		return host == ResolveHost(envHost)
+	}
+	return host == DefaultHost
+}
+
+// ResolveHost returns the GitLab host to query: host when given, otherwise
+// GITLAB_HOST, otherwise gitlab.com. A scheme and trailing slash are
+// stripped.
+func ResolveHost(host string) string {
+	if host == "" {
+		host = os.Getenv("GITLAB_HOST")
+	}
+	if host == "" {
+		host = DefaultHost
+	}
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	return strings.TrimSuffix(host, "/")
+}
+
+func NewClient(host string) *Client {
+	host = ResolveHost(host)
+	return &Client{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		host:    host,
+		baseURL: fmt.Sprintf("https://%s/api/v4", host),
+	}
+}
+
+// authToken returns the token requests are authenticated with, resolving it
+// with getGitLabToken on first use.
+func (c *Client) authToken() string {
+	c.tokenOnce.Do(func() {
+		if c.token == "" {
+			c.token = getGitLabToken(c.host)
+		}
+	})
+	return c.token
+}
+
+// projectURL builds an API URL for a project addressed by its full path.
+// Nested group paths are URL-encoded into a single path segment.
+func (c *Client) projectURL(name string, suffix string) (string, error) {
+	name = strings.Trim(name, "/")
+	if !strings.Contains(name, "/") {
+		return "", fmt.Errorf("invalid project format: expected 'group/project', got '%s'", name)
+	}
+	return fmt.Sprintf("%s/projects/%s%s", c.baseURL, url.PathEscape(name), suffix), nil
+}
+
+func (c *Client) GetPackageInfo(name string) (*Project, error) {
+	projectURL, err := c.projectURL(name, "?license=true")
+	if err != nil {
+		return nil, err
+	}
+
+	var project Project
+	if err := c.fetchJSON(projectURL, &project); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, fmt.Errorf("project '%s' not found", name)
+		}
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (c *Client) GetVersions(name string) ([]formatter.VersionItem, error) {
+	releasesURL, err := c.projectURL(name, "/releases?per_page=100")
+	if err != nil {
+		return nil, err
+	}
+	tagsURL, _ := c.projectURL(name, "/repository/tags?per_page=100")
+
+	var (
+		releases []Release
+		tags     []Tag
+	)
+
+	if err := c.fetchJSON(releasesURL, &releases); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, fmt.Errorf("project '%s' not found", name)
+		}
+		return nil, fmt.Errorf("failed to fetch releases: %w", err)
+	}
+
+	if err := c.fetchJSON(tagsURL, &tags); err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	releaseTags := make(map[string]struct{})
+	versions := []formatter.VersionItem{}
+
+	for _, release := range releases {
+		// The tag of an upcoming release is not a version yet either.
+		releaseTags[release.TagName] = struct{}{}
+		if release.UpcomingRelease {
+			continue
+		}
+		versions = append(versions, formatter.VersionItem{
+			Version:    release.TagName,
+			UploadDate: release.ReleasedAt,
+		})
+	}
+
+	for _, tag := range tags {
+		if _, ok := releaseTags[tag.Name]; ok {
+			continue
+		}
+		versions = append(versions, formatter.VersionItem{
+			Version:    tag.Name,
+			UploadDate: tag.Commit.CreatedAt,
+		})
+	}
+
+	return versions, nil
+}
+
+func (c *Client) GetLatestVersion(name string) (string, error) {
+	latestURL, err := c.projectURL(name, "/releases/permalink/latest")
+	if err != nil {
+		return "", err
+	}
+
+	var release Release
+	if err := c.fetchJSON(latestURL, &release); err == nil && release.TagName != "" {
+		return release.TagName, nil
+	}
+
+	versions, err := c.GetVersions(name)
+	if err != nil {
+		return "", err
+	}
+
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no versions found for project '%s'", name)
+	}
+
+	return versions[0].Version, nil
+}
+
+// errNotFound is returned by fetchJSON when the API responds with 404.
+var errNotFound = errors.New("not found")
+
+func (c *Client) fetchJSON(url string, v any) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if token := c.authToken(); token != "" {
+		req.Header.Set("PRIVATE-TOKEN", token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return errNotFound
+	case http.StatusUnauthorized:
+		return fmt.Errorf("authentication failed, check GITLAB_TOKEN")
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		return fmt.Errorf("access denied or rate limit exceeded, consider setting GITLAB_TOKEN environment variable")
+	default:
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveHost(t *testing.T) {
+	t.Setenv("GITLAB_HOST", "")
+	tests := map[string]string{
+		"":                            DefaultHost,
+		"gitlab.example.com":          "gitlab.example.com",
+		"https://gitlab.example.com/": "gitlab.example.com",
+	}
+	for in, want := range tests {
+		if got := ResolveHost(in); got != want {
+			t.Errorf("ResolveHost(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	t.Setenv("GITLAB_HOST", "git.internal")
+	if got := ResolveHost(""); got != "git.internal" {
+		t.Errorf("ResolveHost with GITLAB_HOST = %q", got)
+	}
+}
+
+func TestEnvTokenApplies(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "secret")
+	t.Setenv("PATH", "")
+
+	t.Setenv("GITLAB_HOST", "")
+	if got := getGitLabToken(DefaultHost); got != "secret" {
+		t.Errorf("token for %s = %q, want secret", DefaultHost, got)
+	}
+	if got := getGitLabToken("gitlab.example.com"); got != "" {
+		t.Errorf("token for another host = %q, want none", got)
+	}
+
+	t.Setenv("GITLAB_HOST", "https://gitlab.example.com/")
+	if got := getGitLabToken("gitlab.example.com"); got != "secret" {
+		t.Errorf("token for GITLAB_HOST = %q, want secret", got)
+	}
+	if got := getGitLabToken(DefaultHost); got != "" {
+		t.Errorf("token for %s with GITLAB_HOST set = %q, want none", DefaultHost, got)
+	}
+}
+
+func TestGetVersionsNestedGroup(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Fsub%2Fproject/releases":
+			fmt.Fprint(w, `[
+				{"tag_name": "v1.1.0", "released_at": "2024-03-01T00:00:00Z", "upcoming_release": true},
+				{"tag_name": "v1.0.0", "released_at": "2024-02-01T00:00:00Z"}
+			]`)
+		case "/api/v4/projects/group%2Fsub%2Fproject/repository/tags":
+			fmt.Fprint(w, `[
+				{"name": "v1.1.0", "commit": {"created_at": "2024-02-28T00:00:00Z"}},
+				{"name": "v1.0.0", "commit": {"created_at": "2024-01-31T00:00:00Z"}},
+				{"name": "v0.9.0", "commit": {"created_at": "2023-12-01T00:00:00Z"}}
+			]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := &Client{httpClient: srv.Client(), baseURL: srv.URL + "/api/v4", token: "secret"}
+	versions, err := client.GetVersions("group/sub/project")
+	if err != nil {
+		t.Fatalf("GetVersions() error = %v (paths %v)", err, paths)
+	}
+
+	want := []string{"v1.0.0", "v0.9.0"}
+	if len(versions) != len(want) {
+		t.Fatalf("got %+v, want %v", versions, want)
+	}
+	for i, v := range versions {
+		if v.Version != want[i] {
+			t.Errorf("versions[%d] = %s, want %s", i, v.Version, want[i])
+		}
+	}
+
+	if _, err := client.GetVersions("missing/project"); err == nil || err.Error() != "project 'missing/project' not found" {
+		t.Errorf("missing project error = %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package gitlab
+
+import "time"
+
+type Project struct {
+	ID                int64      `json:"id"`
+	Name              string     `json:"name"`
+	NameWithNamespace string     `json:"name_with_namespace"`
+	Path              string     `json:"path"`
+	PathWithNamespace string     `json:"path_with_namespace"`
+	Description       string     `json:"description"`
+	DefaultBranch     string     `json:"default_branch"`
+	Visibility        string     `json:"visibility"`
+	WebURL            string     `json:"web_url"`
+	HTTPURLToRepo     string     `json:"http_url_to_repo"`
+	Topics            []string   `json:"topics"`
+	CreatedAt         time.Time  `json:"created_at"`
+	LastActivityAt    time.Time  `json:"last_activity_at"`
+	Archived          bool       `json:"archived"`
+	StarCount         int        `json:"star_count"`
+	ForksCount        int        `json:"forks_count"`
+	OpenIssuesCount   int        `json:"open_issues_count"`
+	Namespace         Namespace  `json:"namespace"`
+	Owner             *User      `json:"owner"`
+	License           *License   `json:"license"`
+	ForkedFromProject *ForkedRef `json:"forked_from_project"`
+}
+
+type Namespace struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Kind     string `json:"kind"`
+	FullPath string `json:"full_path"`
+	WebURL   string `json:"web_url"`
+}
+
+type User struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	WebURL   string `json:"web_url"`
+}
+
+type License struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Nickname string `json:"nickname"`
+	HTMLURL  string `json:"html_url"`
+}
+
+type ForkedRef struct {
+	ID                int64  `json:"id"`
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+type Release struct {
+	Name            string `json:"name"`
+	TagName         string `json:"tag_name"`
+	Description     string `json:"description"`
+	CreatedAt       string `json:"created_at"`
+	ReleasedAt      string `json:"released_at"`
+	UpcomingRelease bool   `json:"upcoming_release"`
+	Author          *User  `json:"author"`
+	Commit          Commit `json:"commit"`
+}
+
+type Tag struct {
+	Name    string   `json:"name"`
+	Message string   `json:"message"`
+	Target  string   `json:"target"`
+	Commit  Commit   `json:"commit"`
+	Release *TagNote `json:"release"`
+}
+
+type TagNote struct {
+	TagName     string `json:"tag_name"`
+	Description string `json:"description"`
+}
+
+type Commit struct {
+	ID            string `json:"id"`
+	ShortID       string `json:"short_id"`
+	Title         string `json:"title"`
+	CreatedAt     string `json:"created_at"`
+	CommittedDate string `json:"committed_date"`
+	AuthoredDate  string `json:"authored_date"`
+}
@@ -0,0 +1,626 @@
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+func parsePackageLock(data []byte) ([]Dependency, error) {
+	type entry struct {
+		// Name is the real name of a package installed under an alias.
+		Name                 string            `json:"name"`
+		Version              string            `json:"version"`
+		Resolved             string            `json:"resolved"`
+		Dev                  bool              `json:"dev"`
+		Optional             bool              `json:"optional"`
+		Link                 bool              `json:"link"`
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	var lock struct {
+		Packages map[string]entry `json:"packages"`
+		// Dependencies is the tree of lockfile version 1, keyed by name.
+		Dependencies map[string]packageLockV1Entry `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+
+	if lock.Packages == nil {
+		return packageLockV1Dependencies(nil, lock.Dependencies, false), nil
+	}
+
+	// The root package lists the direct dependencies with their ranges.
+	root := lock.Packages[""]
+	direct := make(map[string]Dependency)
+	for _, group := range []struct {
+		deps  map[string]string
+		scope string
+	}{
+		{root.Dependencies, Runtime},
+		{root.DevDependencies, Development},
+		{root.OptionalDependencies, Optional},
+	} {
+		for name, req := range group.deps {
+			if _, aliased, ok := npmAlias(req); ok {
+				req = aliased
+			}
+			direct[name] = Dependency{Requirements: req, Scope: group.scope}
+		}
+	}
+
+	var deps []Dependency
+	for _, key := range sortedKeys(lock.Packages) {
+		e := lock.Packages[key]
+		// Links point at workspace packages.
+		if !strings.HasPrefix(key, "node_modules/") || e.Link {
+			continue
+		}
+		// Nested node_modules hold copies for conflicting ranges.
+		// Aliased packages are installed under their alias.
+		name := key[strings.LastIndex(key, "node_modules/")+len("node_modules/"):]
+		dep := Dependency{Ecosystem: "npm", Name: name, Version: e.Version, Scope: lockScope(e.Dev, e.Optional), Indirect: true, Source: npmSource(e.Resolved)}
+		if e.Name != "" {
+			dep.Name = e.Name
+		}
+		if d, ok := direct[name]; ok && !strings.Contains(key, "/node_modules/") {
+			dep.Requirements, dep.Scope, dep.Indirect = d.Requirements, d.Scope, false
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// packageLockV1Entry is a package of lockfile version 1, with the copies
+// nested under it for conflicting ranges.
+type packageLockV1Entry struct {
+	Version      string                        `json:"version"`
+	Resolved     string                        `json:"resolved"`
+	Dev          bool                          `json:"dev"`
+	Optional     bool                          `json:"optional"`
+	Dependencies map[string]packageLockV1Entry `json:"dependencies"`
+}
+
+func packageLockV1Dependencies(deps []Dependency, entries map[string]packageLockV1Entry, nested bool) []Dependency {
+	for _, name := range sortedKeys(entries) {
+		e := entries[name]
+		dep := Dependency{Ecosystem: "npm", Name: name, Version: e.Version, Scope: lockScope(e.Dev, e.Optional), Indirect: nested, Source: npmSource(e.Resolved)}
+		// Version 1 records aliases as "npm:<name>@<version>" and git
+		// dependencies by their URL in place of the version.
+		if real, version, ok := npmAlias(e.Version); ok {
+			dep.Name, dep.Version = real, version
+		} else if strings.Contains(e.Version, ":") {
+			dep.Source = e.Version
+		}
+		deps = append(deps, dep)
+		deps = packageLockV1Dependencies(deps, e.Dependencies, true)
+	}
+	return deps
+}
+
+// npmSource returns resolved when it is not the tarball of a registry, such
+// as a git URL ("git+ssh://..."), a local path ("file:...") or a tarball
+// served elsewhere. Registry tarballs live under "<name>/-/".
+func npmSource(resolved string) string {
+	if resolved == "" {
+		return ""
+	}
+	if u, err := url.Parse(resolved); err == nil && (u.Scheme == "https" || u.Scheme == "http") && strings.Contains(u.Path, "/-/") {
+		return ""
+	}
+	return resolved
+}
+
+func lockScope(dev, optional bool) string {
+	switch {
+	case dev:
+		return Development
+	case optional:
+		return Optional
+	default:
+		return Runtime
+	}
+}
+
+// parsePnpmLock reads the dependencies of the root project of a pnpm
+// lockfile: those of the "." importer in lockfile versions 6 and later, or
+// the top-level ones of single-project lockfiles. The other packages it
+// locks are indirect. It understands the small subset of YAML pnpm writes,
+// so that no YAML library is needed.
+func parsePnpmLock(data []byte) ([]Dependency, error) {
+	var (
+		deps       []Dependency
+		specifiers = make(map[string]string)
+		// base is the indentation of the dependency groups of the root
+		// project, -1 outside of it.
+		base     = -1
+		scope    string
+		section  string
+		current  *Dependency
+		inImport bool
+		// v5 tells lockfile version 5, which names packages "/name/version"
+		// rather than "/name@version".
+		v5     bool
+		locked []Dependency
+	)
+	flush := func() {
+		if current != nil && !strings.HasPrefix(current.Version, "link:") {
+			deps = append(deps, *current)
+		}
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		key, value, _ := strings.Cut(trimmed, ":")
+		key = unquote(key)
+		value = unquote(strings.TrimSpace(value))
+
+		switch {
+		case indent == 0:
+			flush()
+			section, scope, base = key, "", -1
+			inImport = false
+			if key == "lockfileVersion" {
+				v5 = strings.HasPrefix(value, "5")
+			}
+			if group, ok := pnpmGroups[key]; ok {
+				scope, base = group, 0
+			}
+			continue
+		case section == "importers" && indent == 2:
+			flush()
+			inImport, scope, base = key == ".", "", -1
+			continue
+		case inImport && indent == 4:
+			flush()
+			scope, base = pnpmGroups[key], -1
+			if scope != "" {
+				base = 4
+			}
+			continue
+		case section == "specifiers" && indent == 2:
+			specifiers[key] = value
+			continue
+		case section == "packages" && indent == 2:
+			// Keys hold a colon of their own when quoted.
+			key = unquote(strings.TrimSuffix(trimmed, ":"))
+			if name, version, ok := pnpmPackage(key, v5); ok {
+				locked = append(locked, Dependency{Ecosystem: "npm", Name: name, Version: version, Scope: Runtime, Indirect: true})
+			}
+			continue
+		}
+
+		switch {
+		case base < 0 || scope == "":
+		case indent == base+2:
+			flush()
+			current = &Dependency{Ecosystem: "npm", Name: key, Scope: scope}
+			// Lockfile version 5 maps names straight to versions.
+			if value != "" {
+				current.Version = pnpmVersion(value)
+				current.Requirements = specifiers[key]
+			}
+		case current != nil && indent == base+4 && key == "specifier":
+			current.Requirements = value
+		case current != nil && indent == base+4 && key == "version":
+			current.Version = pnpmVersion(value)
+		}
+	}
+	flush()
+
+	direct := make(map[string]bool, len(deps))
+	for _, dep := range deps {
+		direct[dep.Name+"@"+dep.Version] = true
+	}
+	for _, dep := range locked {
+		if !direct[dep.Name+"@"+dep.Version] {
+			deps = append(deps, dep)
+		}
+	}
+	return deps, scanner.Err()
+}
+
+// pnpmPackage splits a key of the packages section, "name@version" or
+// "/name@version" with peer dependencies in parentheses, or "/name/version"
+// in lockfile version 5. Packages not from the registry, e.g. tarballs and
+// local directories, are skipped.
+func pnpmPackage(key string, v5 bool) (name, version string, ok bool) {
+	key, fromRegistry := strings.CutPrefix(key, "/")
+	if strings.Contains(key, ":") || (v5 && !fromRegistry) {
+		return "", "", false
+	}
+	if i := strings.Index(key, "("); i > 0 {
+		key = key[:i]
+	}
+	sep := "@"
+	if v5 {
+		sep = "/"
+	}
+	i := strings.LastIndex(key, sep)
+	if i <= 0 {
+		return "", "", false
+	}
+	return key[:i], pnpmVersion(key[i+1:]), true
+}
+
+var pnpmGroups = map[string]string{
+	"dependencies":         Runtime,
+	"devDependencies":      Development,
+	"optionalDependencies": Optional,
+}
+
+// pnpmVersion strips the peer dependency suffix pnpm appends to versions,
+// "(react@18.2.0)" or, in lockfile version 5, "_react@18.2.0".
+func pnpmVersion(v string) string {
+	if i := strings.IndexAny(v, "(_"); i > 0 {
+		return v[:i]
+	}
+	return v
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func parseCargoLock(data []byte) ([]Dependency, error) {
+	var lock struct {
+		Package []struct {
+			Name         string   `toml:"name"`
+			Version      string   `toml:"version"`
+			Source       string   `toml:"source"`
+			Dependencies []string `toml:"dependencies"`
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+
+	// Packages without a source are the workspace's own; what they depend
+	// on is direct. Entries read "name", or "name version" when ambiguous.
+	direct := make(map[string]bool)
+	for _, p := range lock.Package {
+		if p.Source == "" {
+			for _, d := range p.Dependencies {
+				name, _, _ := strings.Cut(d, " ")
+				direct[name] = true
+			}
+		}
+	}
+
+	var deps []Dependency
+	for _, p := range lock.Package {
+		if p.Source == "" {
+			continue
+		}
+		dep := Dependency{Ecosystem: "cargo", Name: p.Name, Version: p.Version, Scope: Runtime, Indirect: !direct[p.Name]}
+		if !cratesIOSources[p.Source] {
+			dep.Source = p.Source
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// cratesIOSources are the sources Cargo.lock records crates.io packages
+// with, through its git index or its sparse one. Packages from other
+// registries, git repositories or paths are not on crates.io.
+var cratesIOSources = map[string]bool{
+	"registry+https://github.com/rust-lang/crates.io-index": true,
+	"sparse+https://index.crates.io/":                       true,
+}
+
+func parseUvLock(data []byte) ([]Dependency, error) {
+	type ref struct {
+		Name string `toml:"name"`
+	}
+	type requirement struct {
+		Name      string `toml:"name"`
+		Specifier string `toml:"specifier"`
+	}
+	var lock struct {
+		Package []struct {
+			Name                 string           `toml:"name"`
+			Version              string           `toml:"version"`
+			Source               map[string]any   `toml:"source"`
+			Dependencies         []ref            `toml:"dependencies"`
+			OptionalDependencies map[string][]ref `toml:"optional-dependencies"`
+			DevDependencies      map[string][]ref `toml:"dev-dependencies"`
+			Metadata             struct {
+				RequiresDist []requirement            `toml:"requires-dist"`
+				RequiresDev  map[string][]requirement `toml:"requires-dev"`
+			} `toml:"metadata"`
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+
+	// The project itself is an editable or virtual package; its
+	// dependencies are the direct ones.
+	direct := make(map[string]Dependency)
+	isProject := func(source map[string]any) bool {
+		return source["editable"] != nil || source["virtual"] != nil
+	}
+	for _, p := range lock.Package {
+		if !isProject(p.Source) {
+			continue
+		}
+		specifiers := make(map[string]string)
+		for _, r := range p.Metadata.RequiresDist {
+			specifiers[r.Name] = r.Specifier
+		}
+		for _, group := range p.Metadata.RequiresDev {
+			for _, r := range group {
+				specifiers[r.Name] = r.Specifier
+			}
+		}
+		add := func(refs []ref, scope string) {
+			for _, r := range refs {
+				if _, ok := direct[r.Name]; !ok {
+					direct[r.Name] = Dependency{Requirements: specifiers[r.Name], Scope: scope}
+				}
+			}
+		}
+		add(p.Dependencies, Runtime)
+		for _, group := range sortedKeys(p.OptionalDependencies) {
+			add(p.OptionalDependencies[group], Optional)
+		}
+		for _, group := range sortedKeys(p.DevDependencies) {
+			add(p.DevDependencies[group], Development)
+		}
+	}
+
+	var deps []Dependency
+	for _, p := range lock.Package {
+		if isProject(p.Source) {
+			continue
+		}
+		dep := Dependency{Ecosystem: "pypi", Name: p.Name, Version: p.Version, Scope: Runtime, Indirect: true, Source: uvSource(p.Source)}
+		if d, ok := direct[p.Name]; ok {
+			dep.Requirements, dep.Scope, dep.Indirect = d.Requirements, d.Scope, false
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// uvSource describes where uv.lock locks a package from when that is not a
+// package index, e.g. "git+https://github.com/org/repo?rev=main#abc123" for
+// a git source. Other sources are "path", "directory" and "url".
+func uvSource(source map[string]any) string {
+	if source["registry"] != nil {
+		return ""
+	}
+	for _, kind := range []string{"git", "url", "path", "directory"} {
+		if location, ok := source[kind].(string); ok {
+			return kind + "+" + location
+		}
+	}
+	return "unknown"
+}
+
+// parsePoetryLock reads the locked packages of a Poetry lockfile, which does
+// not tell direct dependencies apart; pyproject.toml does.
+func parsePoetryLock(data []byte) ([]Dependency, error) {
+	var lock struct {
+		Package []struct {
+			Name     string `toml:"name"`
+			Version  string `toml:"version"`
+			Category string `toml:"category"`
+			Optional bool   `toml:"optional"`
+			Source   struct {
+				Type              string `toml:"type"`
+				URL               string `toml:"url"`
+				ResolvedReference string `toml:"resolved_reference"`
+			} `toml:"source"`
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+
+	var deps []Dependency
+	for _, p := range lock.Package {
+		dep := Dependency{Ecosystem: "pypi", Name: p.Name, Version: p.Version, Scope: lockScope(p.Category == "dev", p.Optional)}
+		// Packages from a package index other than PyPI have the "legacy"
+		// type, as uv.lock registry sources they are looked up by name.
+		switch p.Source.Type {
+		case "", "legacy":
+		case "git":
+			dep.Source = "git+" + p.Source.URL
+			if p.Source.ResolvedReference != "" {
+				dep.Source += "#" + p.Source.ResolvedReference
+			}
+		default:
+			dep.Source = p.Source.Type + "+" + p.Source.URL
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// parseGemfileLock reads the locked gems, along with the requirements of the
+// direct ones listed under DEPENDENCIES. Gems of GIT and PATH sections have
+// their remote as source.
+func parseGemfileLock(data []byte) ([]Dependency, error) {
+	var (
+		deps     []Dependency
+		section  string
+		remote   string
+		revision string
+		inSpecs  bool
+		direct   = make(map[string]string)
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if indent == 0 {
+			section, remote, revision, inSpecs = trimmed, "", "", false
+			continue
+		}
+
+		// Specs are "name (version)", the platform appended to the version
+		// of native gems, e.g. "nokogiri (1.16.0-x86_64-linux)".
+		name, rest, _ := strings.Cut(trimmed, " ")
+		paren := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(rest), "("), ")")
+		locked := section == "GEM" || section == "GIT" || section == "PATH"
+		switch {
+		case locked && indent == 2:
+			inSpecs = trimmed == "specs:"
+			switch name {
+			case "remote:":
+				remote = strings.TrimSpace(rest)
+			case "revision:":
+				revision = strings.TrimSpace(rest)
+			}
+		case locked && inSpecs && indent == 4:
+			version, _, _ := strings.Cut(paren, "-")
+			dep := Dependency{Ecosystem: "gem", Name: name, Version: version, Scope: Runtime}
+			switch section {
+			case "GIT":
+				dep.Source = "git+" + remote
+				if revision != "" {
+					dep.Source += "#" + revision
+				}
+			case "PATH":
+				dep.Source = "path+" + remote
+			}
+			deps = append(deps, dep)
+		case section == "DEPENDENCIES" && indent == 2:
+			// A trailing "!" marks gems from a git or path source.
+			direct[strings.TrimSuffix(name, "!")] = paren
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range deps {
+		req, ok := direct[deps[i].Name]
+		deps[i].Requirements, deps[i].Indirect = req, !ok
+	}
+	return deps, nil
+}
+
+// parseComposerLock reads the locked packages of a Composer lockfile, which
+// does not tell direct dependencies apart.
+func parseComposerLock(data []byte) ([]Dependency, error) {
+	type location struct {
+		Type      string `json:"type"`
+		URL       string `json:"url"`
+		Reference string `json:"reference"`
+	}
+	type pkg struct {
+		Name    string   `json:"name"`
+		Version string   `json:"version"`
+		Source  location `json:"source"`
+		Dist    location `json:"dist"`
+		// NotificationURL is where Composer reports installs, Packagist
+		// for the packages it serves.
+		NotificationURL string `json:"notification-url"`
+	}
+	var lock struct {
+		Packages    []pkg `json:"packages"`
+		PackagesDev []pkg `json:"packages-dev"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+
+	var deps []Dependency
+	for _, group := range []struct {
+		packages []pkg
+		scope    string
+	}{
+		{lock.Packages, Runtime},
+		{lock.PackagesDev, Development},
+	} {
+		for _, p := range group.packages {
+			dep := Dependency{Ecosystem: "composer", Name: p.Name, Version: p.Version, Scope: group.scope}
+			switch {
+			case p.Dist.Type == "path":
+				dep.Source = "path+" + p.Dist.URL
+			case p.NotificationURL != packagistNotificationURL && p.Source.Type != "":
+				// Packages of vcs and other repositories are not on
+				// Packagist.
+				dep.Source = p.Source.Type + "+" + p.Source.URL
+				if p.Source.Reference != "" {
+					dep.Source += "#" + p.Source.Reference
+				}
+			case p.NotificationURL != packagistNotificationURL && p.Dist.Type != "":
+				dep.Source = p.Dist.Type + "+" + p.Dist.URL
+			}
+			deps = append(deps, dep)
+		}
+	}
+	return deps, nil
+}
+
+// packagistNotificationURL is the notification URL Composer records for
+// packages installed from Packagist.
+const packagistNotificationURL = "https://packagist.org/downloads/"
+
+// parseTerraformLock reads the providers of a Terraform dependency lock
+// file. Providers of the public registry are named "namespace/type".
+func parseTerraformLock(data []byte) ([]Dependency, error) {
+	var (
+		deps    []Dependency
+		current *Dependency
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if source, ok := strings.CutPrefix(line, "provider "); ok {
+			source = strings.Trim(strings.TrimSuffix(source, "{"), " \"")
+			current = &Dependency{
+				Ecosystem: "terraform",
+				Name:      strings.TrimPrefix(source, "registry.terraform.io/"),
+				Scope:     Runtime,
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if line == "}" {
+			deps = append(deps, *current)
+			current = nil
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), "\"")
+		switch strings.TrimSpace(key) {
+		case "version":
+			current.Version = value
+		case "constraints":
+			current.Requirements = value
+		}
+	}
+	return deps, scanner.Err()
+}
@@ -0,0 +1,437 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLockfiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     string
+		want     []Dependency
+	}{
+		{
+			name:     "package-lock.json v3",
+			filename: "package-lock.json",
+			data: `{"lockfileVersion": 3, "packages": {
+  "": {"name": "app", "dependencies": {"react": "^18.2.0"}, "devDependencies": {"jest": "^29.0.0"}},
+  "node_modules/react": {"version": "18.2.0"},
+  "node_modules/loose-envify": {"version": "1.4.0"},
+  "node_modules/jest": {"version": "29.7.0", "dev": true},
+  "node_modules/jest/node_modules/react": {"version": "17.0.2", "dev": true},
+  "node_modules/local": {"resolved": "packages/local", "link": true}
+}}`,
+			want: []Dependency{
+				{Ecosystem: "npm", Name: "jest", Requirements: "^29.0.0", Version: "29.7.0", Scope: Development},
+				{Ecosystem: "npm", Name: "react", Version: "17.0.2", Scope: Development, Indirect: true},
+				{Ecosystem: "npm", Name: "loose-envify", Version: "1.4.0", Scope: Runtime, Indirect: true},
+				{Ecosystem: "npm", Name: "react", Requirements: "^18.2.0", Version: "18.2.0", Scope: Runtime},
+			},
+		},
+		{
+			name:     "package-lock.json v1",
+			filename: "package-lock.json",
+			data: `{"lockfileVersion": 1, "dependencies": {
+  "react": {"version": "18.2.0", "requires": {"loose-envify": "^1.1.0"}},
+  "jest": {"version": "29.7.0", "dev": true, "dependencies": {"react": {"version": "17.0.2", "dev": true}}}
+}}`,
+			want: []Dependency{
+				{Ecosystem: "npm", Name: "jest", Version: "29.7.0", Scope: Development},
+				{Ecosystem: "npm", Name: "react", Version: "17.0.2", Scope: Development, Indirect: true},
+				{Ecosystem: "npm", Name: "react", Version: "18.2.0", Scope: Runtime},
+			},
+		},
+		{
+			name:     "package-lock.json sources and aliases",
+			filename: "package-lock.json",
+			data: `{"lockfileVersion": 3, "packages": {
+  "": {"name": "app", "dependencies": {"lodash": "npm:lodash-es@^4.17.0", "forked": "github:org/forked", "vendored": "file:vendor/vendored.tgz"}},
+  "node_modules/lodash": {"name": "lodash-es", "version": "4.17.21", "resolved": "https://registry.npmjs.org/lodash-es/-/lodash-es-4.17.21.tgz"},
+  "node_modules/forked": {"version": "1.0.0", "resolved": "git+ssh://git@github.com/org/forked.git#0123abcd"},
+  "node_modules/vendored": {"version": "2.0.0", "resolved": "file:vendor/vendored.tgz"},
+  "node_modules/hosted": {"version": "3.0.0", "resolved": "https://example.com/hosted.tgz"}
+}}`,
+			want: []Dependency{
+				{Ecosystem: "npm", Name: "forked", Requirements: "github:org/forked", Version: "1.0.0", Scope: Runtime,
+					Source: "git+ssh://git@github.com/org/forked.git#0123abcd"},
+				{Ecosystem: "npm", Name: "hosted", Version: "3.0.0", Scope: Runtime, Indirect: true, Source: "https://example.com/hosted.tgz"},
+				{Ecosystem: "npm", Name: "lodash-es", Requirements: "^4.17.0", Version: "4.17.21", Scope: Runtime},
+				{Ecosystem: "npm", Name: "vendored", Requirements: "file:vendor/vendored.tgz", Version: "2.0.0", Scope: Runtime, Source: "file:vendor/vendored.tgz"},
+			},
+		},
+		{
+			name:     "package-lock.json v1 sources and aliases",
+			filename: "package-lock.json",
+			data: `{"lockfileVersion": 1, "dependencies": {
+  "lodash": {"version": "npm:lodash-es@4.17.21", "resolved": "https://registry.npmjs.org/lodash-es/-/lodash-es-4.17.21.tgz"},
+  "forked": {"version": "git+ssh://git@github.com/org/forked.git#0123abcd", "from": "github:org/forked"}
+}}`,
+			want: []Dependency{
+				{Ecosystem: "npm", Name: "forked", Version: "git+ssh://git@github.com/org/forked.git#0123abcd", Scope: Runtime,
+					Source: "git+ssh://git@github.com/org/forked.git#0123abcd"},
+				{Ecosystem: "npm", Name: "lodash-es", Version: "4.17.21", Scope: Runtime},
+			},
+		},
+		{
+			name:     "pnpm-lock.yaml importers",
+			filename: "pnpm-lock.yaml",
+			data: `lockfileVersion: '9.0'
+
+importers:
+
+  .:
+    dependencies:
+      react:
+        specifier: ^18.2.0
+        version: 18.2.0
+    devDependencies:
+      '@types/react':
+        specifier: ^18.0.0
+        version: 18.2.79(@types/prop-types@15.7.12)
+      shared:
+        specifier: workspace:*
+        version: link:packages/shared
+
+  packages/shared:
+    dependencies:
+      lodash:
+        specifier: ^4.17.21
+        version: 4.17.21
+
+packages:
+
+  '@types/prop-types@15.7.12':
+    resolution: {integrity: sha512-def}
+
+  '@types/react@18.2.79':
+    resolution: {integrity: sha512-ghi}
+
+  react@18.2.0:
+    resolution: {integrity: sha512-abc}
+
+  vendored@file:vendor/vendored.tgz:
+    resolution: {tarball: file:vendor/vendored.tgz}
+`,
+			want: []Dependency{
+				{Ecosystem: "npm", Name: "react", Requirements: "^18.2.0", Version: "18.2.0", Scope: Runtime},
+				{Ecosystem: "npm", Name: "@types/react", Requirements: "^18.0.0", Version: "18.2.79", Scope: Development},
+				{Ecosystem: "npm", Name: "@types/prop-types", Version: "15.7.12", Scope: Runtime, Indirect: true},
+			},
+		},
+		{
+			name:     "pnpm-lock.yaml version 5",
+			filename: "pnpm-lock.yaml",
+			data: `lockfileVersion: 5.4
+
+specifiers:
+  react: ^18.2.0
+
+dependencies:
+  react: 18.2.0_loose-envify@1.4.0
+
+packages:
+
+  /@babel/runtime/7.24.5:
+    resolution: {integrity: sha512-abc}
+
+  /react/18.2.0_loose-envify@1.4.0:
+    resolution: {integrity: sha512-def}
+
+  github.com/user/repo/0123abc:
+    resolution: {tarball: https://codeload.github.com/user/repo/tar.gz/0123abc}
+`,
+			want: []Dependency{
+				{Ecosystem: "npm", Name: "react", Requirements: "^18.2.0", Version: "18.2.0", Scope: Runtime},
+				{Ecosystem: "npm", Name: "@babel/runtime", Version: "7.24.5", Scope: Runtime, Indirect: true},
+			},
+		},
+		{
+			name:     "Cargo.lock",
+			filename: "Cargo.lock",
+			data: `version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = ["serde", "syn 2.0.60"]
+
+[[package]]
+name = "serde"
+version = "1.0.200"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "syn"
+version = "2.0.60"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "quote"
+version = "1.0.36"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "patched"
+version = "0.3.0"
+source = "git+https://github.com/org/patched?branch=fix#0123abcd"
+`,
+			want: []Dependency{
+				{Ecosystem: "cargo", Name: "serde", Version: "1.0.200", Scope: Runtime},
+				{Ecosystem: "cargo", Name: "syn", Version: "2.0.60", Scope: Runtime},
+				{Ecosystem: "cargo", Name: "quote", Version: "1.0.36", Scope: Runtime, Indirect: true},
+				{Ecosystem: "cargo", Name: "patched", Version: "0.3.0", Scope: Runtime, Indirect: true,
+					Source: "git+https://github.com/org/patched?branch=fix#0123abcd"},
+			},
+		},
+		{
+			name:     "uv.lock",
+			filename: "uv.lock",
+			data: `version = 1
+
+[[package]]
+name = "app"
+version = "0.1.0"
+source = { editable = "." }
+dependencies = [{ name = "requests" }]
+
+[package.dev-dependencies]
+dev = [{ name = "pytest" }]
+
+[package.metadata]
+requires-dist = [{ name = "requests", specifier = ">=2.31" }]
+
+[package.metadata.requires-dev]
+dev = [{ name = "pytest", specifier = ">=8" }]
+
+[[package]]
+name = "requests"
+version = "2.32.3"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "pytest"
+version = "8.2.0"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "idna"
+version = "3.7"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "forked"
+version = "1.0.0"
+source = { git = "https://github.com/org/forked?rev=main#0123abcd" }
+
+[[package]]
+name = "sibling"
+version = "0.2.0"
+source = { directory = "../sibling" }
+`,
+			want: []Dependency{
+				{Ecosystem: "pypi", Name: "requests", Requirements: ">=2.31", Version: "2.32.3", Scope: Runtime},
+				{Ecosystem: "pypi", Name: "pytest", Requirements: ">=8", Version: "8.2.0", Scope: Development},
+				{Ecosystem: "pypi", Name: "idna", Version: "3.7", Scope: Runtime, Indirect: true},
+				{Ecosystem: "pypi", Name: "forked", Version: "1.0.0", Scope: Runtime, Indirect: true,
+					Source: "git+https://github.com/org/forked?rev=main#0123abcd"},
+				{Ecosystem: "pypi", Name: "sibling", Version: "0.2.0", Scope: Runtime, Indirect: true, Source: "directory+../sibling"},
+			},
+		},
+		{
+			name:     "poetry.lock",
+			filename: "poetry.lock",
+			data: `[[package]]
+name = "requests"
+version = "2.32.3"
+optional = false
+
+[[package]]
+name = "pytest"
+version = "8.2.0"
+category = "dev"
+`,
+			want: []Dependency{
+				{Ecosystem: "pypi", Name: "requests", Version: "2.32.3", Scope: Runtime},
+				{Ecosystem: "pypi", Name: "pytest", Version: "8.2.0", Scope: Development},
+			},
+		},
+		{
+			name:     "poetry.lock sources",
+			filename: "poetry.lock",
+			data: `[[package]]
+name = "forked"
+version = "1.0.0"
+
+[package.source]
+type = "git"
+url = "https://github.com/org/forked.git"
+reference = "main"
+resolved_reference = "0123abcd"
+
+[[package]]
+name = "sibling"
+version = "0.2.0"
+
+[package.source]
+type = "directory"
+url = "../sibling"
+
+[[package]]
+name = "wheel-only"
+version = "0.3.0"
+
+[package.source]
+type = "url"
+url = "https://example.com/wheel_only-0.3.0-py3-none-any.whl"
+
+[[package]]
+name = "internal"
+version = "0.4.0"
+
+[package.source]
+type = "legacy"
+url = "https://pypi.example.com/simple"
+reference = "private"
+`,
+			want: []Dependency{
+				{Ecosystem: "pypi", Name: "forked", Version: "1.0.0", Scope: Runtime, Source: "git+https://github.com/org/forked.git#0123abcd"},
+				{Ecosystem: "pypi", Name: "sibling", Version: "0.2.0", Scope: Runtime, Source: "directory+../sibling"},
+				{Ecosystem: "pypi", Name: "wheel-only", Version: "0.3.0", Scope: Runtime, Source: "url+https://example.com/wheel_only-0.3.0-py3-none-any.whl"},
+				{Ecosystem: "pypi", Name: "internal", Version: "0.4.0", Scope: Runtime},
+			},
+		},
+		{
+			name:     "Gemfile.lock",
+			filename: "Gemfile.lock",
+			data: `GEM
+  remote: https://rubygems.org/
+  specs:
+    nokogiri (1.16.4-x86_64-linux)
+      racc (~> 1.4)
+    racc (1.7.3)
+    rails (7.1.3)
+
+PLATFORMS
+  x86_64-linux
+
+DEPENDENCIES
+  nokogiri
+  rails (~> 7.1)
+
+BUNDLED WITH
+   2.5.9
+`,
+			want: []Dependency{
+				{Ecosystem: "gem", Name: "nokogiri", Version: "1.16.4", Scope: Runtime},
+				{Ecosystem: "gem", Name: "racc", Version: "1.7.3", Scope: Runtime, Indirect: true},
+				{Ecosystem: "gem", Name: "rails", Requirements: "~> 7.1", Version: "7.1.3", Scope: Runtime},
+			},
+		},
+		{
+			name:     "Gemfile.lock sources",
+			filename: "Gemfile.lock",
+			data: `GIT
+  remote: https://github.com/rails/rails.git
+  revision: 0123abcd
+  branch: main
+  specs:
+    rails (7.2.0.alpha)
+
+PATH
+  remote: engines/billing
+  specs:
+    billing (0.1.0)
+
+GEM
+  remote: https://rubygems.org/
+  specs:
+    rack (3.0.11)
+
+PLATFORMS
+  ruby
+
+DEPENDENCIES
+  billing!
+  rack
+  rails!
+`,
+			want: []Dependency{
+				{Ecosystem: "gem", Name: "rails", Version: "7.2.0.alpha", Scope: Runtime, Source: "git+https://github.com/rails/rails.git#0123abcd"},
+				{Ecosystem: "gem", Name: "billing", Version: "0.1.0", Scope: Runtime, Source: "path+engines/billing"},
+				{Ecosystem: "gem", Name: "rack", Version: "3.0.11", Scope: Runtime},
+			},
+		},
+		{
+			name:     "composer.lock",
+			filename: "composer.lock",
+			data:     `{"packages": [{"name": "monolog/monolog", "version": "3.6.0"}], "packages-dev": [{"name": "phpunit/phpunit", "version": "11.1.3"}]}`,
+			want: []Dependency{
+				{Ecosystem: "composer", Name: "monolog/monolog", Version: "3.6.0", Scope: Runtime},
+				{Ecosystem: "composer", Name: "phpunit/phpunit", Version: "11.1.3", Scope: Development},
+			},
+		},
+		{
+			name:     "composer.lock sources",
+			filename: "composer.lock",
+			data: `{"packages": [
+  {"name": "monolog/monolog", "version": "3.6.0",
+   "source": {"type": "git", "url": "https://github.com/Seldaek/monolog.git", "reference": "4b18b21"},
+   "dist": {"type": "zip", "url": "https://api.github.com/repos/Seldaek/monolog/zipball/4b18b21"},
+   "notification-url": "https://packagist.org/downloads/"},
+  {"name": "acme/forked", "version": "dev-main",
+   "source": {"type": "git", "url": "https://github.com/acme/forked.git", "reference": "0123abcd"}},
+  {"name": "acme/local", "version": "dev-main",
+   "dist": {"type": "path", "url": "packages/local", "reference": "fedcba98"}}
+]}`,
+			want: []Dependency{
+				{Ecosystem: "composer", Name: "monolog/monolog", Version: "3.6.0", Scope: Runtime},
+				{Ecosystem: "composer", Name: "acme/forked", Version: "dev-main", Scope: Runtime, Source: "git+https://github.com/acme/forked.git#0123abcd"},
+				{Ecosystem: "composer", Name: "acme/local", Version: "dev-main", Scope: Runtime, Source: "path+packages/local"},
+			},
+		},
+		{
+			name:     ".terraform.lock.hcl",
+			filename: ".terraform.lock.hcl",
+			data: `# This file is maintained automatically by "terraform init".
+
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "5.47.0"
+  constraints = "~> 5.0"
+  hashes = [
+    "h1:abc=",
+  ]
+}
+`,
+			want: []Dependency{
+				{Ecosystem: "terraform", Name: "hashicorp/aws", Requirements: "~> 5.0", Version: "5.47.0", Scope: Runtime},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.filename, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsLockfile(t *testing.T) {
+	for name, want := range map[string]bool{
+		"app/package-lock.json": true,
+		"Gemfile.lock":          true,
+		"package.json":          false,
+		"requirements-dev.txt":  false,
+		"Gemfile":               false,
+	} {
+		if got := IsLockfile(name); got != want {
+			t.Errorf("IsLockfile(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
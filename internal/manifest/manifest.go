@@ -0,0 +1,120 @@
+// Package manifest parses the dependency declarations of common project
+// manifests into a uniform list of requirements.
+package manifest
+
+import (
+	"fmt"
+	"path"
+	"sort"
+)
+
+// Dependency scopes, matching the scope names used by registries.
+const (
+	Runtime     = "runtime"
+	Development = "development"
+	Test        = "test"
+	Build       = "build"
+	Optional    = "optional"
+)
+
+// Dependency is a single requirement declared by a manifest.
+type Dependency struct {
+	// Ecosystem is the package URL type of the dependency (e.g. "npm",
+	// "golang", "cargo", "pypi").
+	Ecosystem string
+	Name      string
+	// Requirements is the version constraint as written in the manifest, in
+	// the ecosystem's native syntax. It is empty when none is declared.
+	Requirements string
+	// Version is the exact version in use, as locked by a lockfile or pinned
+	// by the manifest. It is empty when unknown.
+	Version string
+	Scope   string
+	// Indirect marks requirements the manifest records only to pin a
+	// transitive dependency, such as go.mod "// indirect" entries, and
+	// packages a lockfile locks for another one.
+	Indirect bool
+	// Source is where a lockfile locks the package from when that is not
+	// the ecosystem's public registry, e.g. a git repository or a local
+	// path. Such packages are not looked up in the registry.
+	Source string
+}
+
+type parser func(data []byte) ([]Dependency, error)
+
+// format is a manifest or lockfile format, recognized by a file name
+// pattern as understood by [path.Match].
+type format struct {
+	pattern string
+	parse   parser
+	// lockfile marks formats that lock the versions of a manifest's
+	// dependencies along with their transitive ones.
+	lockfile bool
+}
+
+var formats = []format{
+	{pattern: "go.mod", parse: parseGoMod},
+	{pattern: "package.json", parse: parsePackageJSON},
+	{pattern: "package-lock.json", parse: parsePackageLock, lockfile: true},
+	{pattern: "pnpm-lock.yaml", parse: parsePnpmLock, lockfile: true},
+	{pattern: "Cargo.toml", parse: parseCargoToml},
+	{pattern: "Cargo.lock", parse: parseCargoLock, lockfile: true},
+	{pattern: "pyproject.toml", parse: parsePyproject},
+	{pattern: "requirements*.txt", parse: parseRequirements},
+	{pattern: "uv.lock", parse: parseUvLock, lockfile: true},
+	{pattern: "poetry.lock", parse: parsePoetryLock, lockfile: true},
+	{pattern: "Gemfile.lock", parse: parseGemfileLock, lockfile: true},
+	{pattern: "composer.lock", parse: parseComposerLock, lockfile: true},
+	{pattern: "pom.xml", parse: parsePom},
+	{pattern: ".terraform.lock.hcl", parse: parseTerraformLock, lockfile: true},
+}
+
+func formatOf(filename string) (format, bool) {
+	base := path.Base(filename)
+	for _, f := range formats {
+		if ok, _ := path.Match(f.pattern, base); ok {
+			return f, true
+		}
+	}
+	return format{}, false
+}
+
+// Supported returns the file name patterns of the manifests and lockfiles
+// Parse understands.
+func Supported() []string {
+	names := make([]string, 0, len(formats))
+	for _, f := range formats {
+		names = append(names, f.pattern)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsSupported reports whether the base name of filename is a manifest or
+// lockfile Parse understands.
+func IsSupported(filename string) bool {
+	_, ok := formatOf(filename)
+	return ok
+}
+
+// IsLockfile reports whether the base name of filename is a lockfile Parse
+// understands. Lockfiles list transitive dependencies too, marked Indirect
+// when the lockfile tells them apart.
+func IsLockfile(filename string) bool {
+	f, ok := formatOf(filename)
+	return ok && f.lockfile
+}
+
+// Parse extracts the dependencies declared in the manifest or lockfile data,
+// choosing the format from the base name of filename.
+func Parse(filename string, data []byte) ([]Dependency, error) {
+	f, ok := formatOf(filename)
+	if !ok {
+		return nil, fmt.Errorf("unsupported manifest: %s", filename)
+	}
+	deps, err := f.parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	return deps, nil
+}
@@ -0,0 +1,154 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     string
+		want     []Dependency
+	}{
+		{
+			name:     "go.mod block and single requires",
+			filename: "go.mod",
+			data: `module example.com/x
+
+go 1.22
+
+require github.com/spf13/cobra v1.8.0
+
+require (
+	golang.org/x/sys v0.20.0 // indirect
+	github.com/google/go-cmp v0.6.0
+)
+`,
+			want: []Dependency{
+				{Ecosystem: "golang", Name: "github.com/spf13/cobra", Requirements: "v1.8.0", Version: "v1.8.0", Scope: Runtime},
+				{Ecosystem: "golang", Name: "golang.org/x/sys", Requirements: "v0.20.0", Version: "v0.20.0", Scope: Runtime, Indirect: true},
+				{Ecosystem: "golang", Name: "github.com/google/go-cmp", Requirements: "v0.6.0", Version: "v0.6.0", Scope: Runtime},
+			},
+		},
+		{
+			name:     "package.json groups",
+			filename: "sub/package.json",
+			data:     `{"dependencies": {"react": "^18.2.0", "@babel/core": "7.x", "left-pad": "1.3.0"}, "devDependencies": {"jest": "~29"}}`,
+			want: []Dependency{
+				{Ecosystem: "npm", Name: "@babel/core", Requirements: "7.x", Scope: Runtime},
+				{Ecosystem: "npm", Name: "left-pad", Requirements: "1.3.0", Version: "1.3.0", Scope: Runtime},
+				{Ecosystem: "npm", Name: "react", Requirements: "^18.2.0", Scope: Runtime},
+				{Ecosystem: "npm", Name: "jest", Requirements: "~29", Scope: Development},
+			},
+		},
+		{
+			name:     "package.json aliases",
+			filename: "package.json",
+			data:     `{"dependencies": {"lodash": "npm:lodash-es@^4.17.0", "types": "npm:@types/node@20.11.0"}}`,
+			want: []Dependency{
+				{Ecosystem: "npm", Name: "lodash-es", Requirements: "^4.17.0", Scope: Runtime},
+				{Ecosystem: "npm", Name: "@types/node", Requirements: "20.11.0", Version: "20.11.0", Scope: Runtime},
+			},
+		},
+		{
+			name:     "Cargo.toml string and table specs",
+			filename: "Cargo.toml",
+			data: `[package]
+name = "x"
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+anyhow = "1"
+tokio = { version = "1.37", optional = true }
+
+[dev-dependencies]
+insta = "1.39"
+
+[target.'cfg(unix)'.dependencies]
+libc = "0.2"
+`,
+			want: []Dependency{
+				{Ecosystem: "cargo", Name: "anyhow", Requirements: "1", Scope: Runtime},
+				{Ecosystem: "cargo", Name: "serde", Requirements: "1.0", Scope: Runtime},
+				{Ecosystem: "cargo", Name: "tokio", Requirements: "1.37", Scope: Optional},
+				{Ecosystem: "cargo", Name: "insta", Requirements: "1.39", Scope: Development},
+				{Ecosystem: "cargo", Name: "libc", Requirements: "0.2", Scope: Runtime},
+			},
+		},
+		{
+			name:     "pyproject PEP 621 and poetry",
+			filename: "pyproject.toml",
+			data: `[project]
+dependencies = ["requests[socks]>=2.31; python_version > '3.8'", "click"]
+
+[project.optional-dependencies]
+docs = ["sphinx (>=7)"]
+
+[tool.poetry.dependencies]
+python = "^3.10"
+httpx = "^0.27"
+`,
+			want: []Dependency{
+				{Ecosystem: "pypi", Name: "requests", Requirements: ">=2.31", Scope: Runtime},
+				{Ecosystem: "pypi", Name: "click", Scope: Runtime},
+				{Ecosystem: "pypi", Name: "sphinx", Requirements: ">=7", Scope: Optional},
+				{Ecosystem: "pypi", Name: "httpx", Requirements: "^0.27", Scope: Runtime},
+			},
+		},
+		{
+			name:     "requirements file",
+			filename: "requirements-dev.txt",
+			data: `# tools
+-r requirements.txt
+black==24.3.0 \
+    --hash=sha256:abc
+pytest>=8  # runner
+-e ./local
+git+https://github.com/x/y.git
+`,
+			want: []Dependency{
+				{Ecosystem: "pypi", Name: "black", Requirements: "==24.3.0", Version: "24.3.0", Scope: Runtime},
+				{Ecosystem: "pypi", Name: "pytest", Requirements: ">=8", Scope: Runtime},
+			},
+		},
+		{
+			name:     "pom.xml properties and managed versions",
+			filename: "pom.xml",
+			data: `<project>
+  <properties><junit.version>5.10.2</junit.version></properties>
+  <dependencyManagement><dependencies>
+    <dependency><groupId>com.google.guava</groupId><artifactId>guava</artifactId><version>33.0.0-jre</version></dependency>
+  </dependencies></dependencyManagement>
+  <dependencies>
+    <dependency><groupId>com.google.guava</groupId><artifactId>guava</artifactId></dependency>
+    <dependency><groupId>org.junit.jupiter</groupId><artifactId>junit-jupiter</artifactId><version>${junit.version}</version><scope>test</scope></dependency>
+    <dependency><groupId>org.slf4j</groupId><artifactId>slf4j-api</artifactId><version>[2.0,3.0)</version></dependency>
+  </dependencies>
+</project>`,
+			want: []Dependency{
+				{Ecosystem: "maven", Name: "com.google.guava:guava", Requirements: "33.0.0-jre", Version: "33.0.0-jre", Scope: Runtime},
+				{Ecosystem: "maven", Name: "org.junit.jupiter:junit-jupiter", Requirements: "5.10.2", Version: "5.10.2", Scope: Test},
+				{Ecosystem: "maven", Name: "org.slf4j:slf4j-api", Requirements: "[2.0,3.0)", Scope: Runtime},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.filename, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUnsupported(t *testing.T) {
+	if _, err := Parse("setup.py", nil); err == nil {
+		t.Error("expected error for unsupported manifest")
+	}
+}
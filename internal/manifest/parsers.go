@@ -0,0 +1,396 @@
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"encoding/xml"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+func parseGoMod(data []byte) ([]Dependency, error) {
+	var (
+		deps    []Dependency
+		inBlock bool
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		var comment string
+		if i := strings.Index(line, "//"); i >= 0 {
+			comment = strings.TrimSpace(line[i+2:])
+			line = strings.TrimSpace(line[:i])
+		}
+
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case line == "require (":
+			inBlock = true
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require"))
+		case !inBlock:
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		deps = append(deps, Dependency{
+			Ecosystem:    "golang",
+			Name:         fields[0],
+			Requirements: fields[1],
+			Version:      fields[1],
+			Scope:        Runtime,
+			Indirect:     comment == "indirect" || strings.HasPrefix(comment, "indirect;"),
+		})
+	}
+	return deps, scanner.Err()
+}
+
+func parsePackageJSON(data []byte) ([]Dependency, error) {
+	var pkg struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, err
+	}
+
+	var deps []Dependency
+	for _, group := range []struct {
+		deps  map[string]string
+		scope string
+	}{
+		{pkg.Dependencies, Runtime},
+		{pkg.DevDependencies, Development},
+		{pkg.OptionalDependencies, Optional},
+	} {
+		for _, name := range sortedKeys(group.deps) {
+			req := group.deps[name]
+			if real, aliased, ok := npmAlias(req); ok {
+				name, req = real, aliased
+			}
+			deps = append(deps, Dependency{
+				Ecosystem:    "npm",
+				Name:         name,
+				Requirements: req,
+				Version:      pinnedVersion(req, "=", semverVersion),
+				Scope:        group.scope,
+			})
+		}
+	}
+	return deps, nil
+}
+
+// npmAlias splits an alias specifier, "npm:<name>@<range>", into the name
+// of the package it installs and its range.
+func npmAlias(spec string) (name, req string, ok bool) {
+	rest, ok := strings.CutPrefix(spec, "npm:")
+	if !ok {
+		return "", "", false
+	}
+	// Scoped names start with "@".
+	at := strings.LastIndex(rest, "@")
+	if at <= 0 {
+		return rest, "", true
+	}
+	return rest[:at], rest[at+1:], true
+}
+
+func parseCargoToml(data []byte) ([]Dependency, error) {
+	type table = map[string]any
+	var manifest struct {
+		Dependencies      table            `toml:"dependencies"`
+		DevDependencies   table            `toml:"dev-dependencies"`
+		BuildDependencies table            `toml:"build-dependencies"`
+		Target            map[string]table `toml:"target"`
+		Workspace         struct {
+			Dependencies table `toml:"dependencies"`
+		} `toml:"workspace"`
+	}
+	if err := toml.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	var deps []Dependency
+	add := func(t table, scope string) {
+		for _, name := range sortedKeys(t) {
+			dep := Dependency{Ecosystem: "cargo", Name: name, Scope: scope}
+			switch spec := t[name].(type) {
+			case string:
+				dep.Requirements = spec
+			case map[string]any:
+				if pkg, ok := spec["package"].(string); ok {
+					dep.Name = pkg
+				}
+				dep.Requirements, _ = spec["version"].(string)
+				if optional, _ := spec["optional"].(bool); optional {
+					dep.Scope = Optional
+				}
+			}
+			// A bare version means ^version to cargo, so only "=" pins.
+			if strings.HasPrefix(dep.Requirements, "=") {
+				dep.Version = pinnedVersion(dep.Requirements, "=", semverVersion)
+			}
+			deps = append(deps, dep)
+		}
+	}
+
+	add(manifest.Dependencies, Runtime)
+	add(manifest.DevDependencies, Development)
+	add(manifest.BuildDependencies, Build)
+	add(manifest.Workspace.Dependencies, Runtime)
+	for _, target := range sortedKeys(manifest.Target) {
+		t := manifest.Target[target]
+		sub := func(key string) table {
+			m, _ := t[key].(map[string]any)
+			return m
+		}
+		add(sub("dependencies"), Runtime)
+		add(sub("dev-dependencies"), Development)
+		add(sub("build-dependencies"), Build)
+	}
+	return deps, nil
+}
+
+func parsePyproject(data []byte) ([]Dependency, error) {
+	var project struct {
+		Project struct {
+			Dependencies         []string            `toml:"dependencies"`
+			OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+		} `toml:"project"`
+		DependencyGroups map[string][]any `toml:"dependency-groups"`
+		Tool             struct {
+			Poetry struct {
+				Dependencies    map[string]any `toml:"dependencies"`
+				DevDependencies map[string]any `toml:"dev-dependencies"`
+				Group           map[string]struct {
+					Dependencies map[string]any `toml:"dependencies"`
+				} `toml:"group"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if err := toml.Unmarshal(data, &project); err != nil {
+		return nil, err
+	}
+
+	var deps []Dependency
+	addPEP508 := func(reqs []string, scope string) {
+		for _, req := range reqs {
+			if dep, ok := ParsePEP508(req); ok {
+				dep.Scope = scope
+				deps = append(deps, dep)
+			}
+		}
+	}
+	addPoetry := func(t map[string]any, scope string) {
+		for _, name := range sortedKeys(t) {
+			if name == "python" {
+				continue
+			}
+			dep := Dependency{Ecosystem: "pypi", Name: name, Scope: scope}
+			switch spec := t[name].(type) {
+			case string:
+				dep.Requirements = spec
+			case map[string]any:
+				dep.Requirements, _ = spec["version"].(string)
+				if optional, _ := spec["optional"].(bool); optional {
+					dep.Scope = Optional
+				}
+			}
+			deps = append(deps, dep)
+		}
+	}
+
+	addPEP508(project.Project.Dependencies, Runtime)
+	for _, group := range sortedKeys(project.Project.OptionalDependencies) {
+		addPEP508(project.Project.OptionalDependencies[group], Optional)
+	}
+	for _, group := range sortedKeys(project.DependencyGroups) {
+		var reqs []string
+		for _, entry := range project.DependencyGroups[group] {
+			// Entries may also be {include-group = "..."} tables.
+			if req, ok := entry.(string); ok {
+				reqs = append(reqs, req)
+			}
+		}
+		addPEP508(reqs, Development)
+	}
+	addPoetry(project.Tool.Poetry.Dependencies, Runtime)
+	addPoetry(project.Tool.Poetry.DevDependencies, Development)
+	for _, group := range sortedKeys(project.Tool.Poetry.Group) {
+		addPoetry(project.Tool.Poetry.Group[group].Dependencies, Development)
+	}
+	return deps, nil
+}
+
+func parseRequirements(data []byte) ([]Dependency, error) {
+	var deps []Dependency
+	// Backslashes continue a requirement on the next line.
+	text := strings.ReplaceAll(string(data), "\\\n", " ")
+	for line := range strings.Lines(text) {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		// Per-requirement options such as --hash follow the requirement.
+		if i := strings.Index(line, " --"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		// Skip options such as -r other.txt, and editable or URL installs.
+		if line == "" || strings.HasPrefix(line, "-") || strings.Contains(line, "://") {
+			continue
+		}
+		if dep, ok := ParsePEP508(line); ok {
+			dep.Scope = Runtime
+			deps = append(deps, dep)
+		}
+	}
+	return deps, nil
+}
+
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Scope      string `xml:"scope"`
+	Optional   string `xml:"optional"`
+}
+
+func parsePom(data []byte) ([]Dependency, error) {
+	var pom struct {
+		GroupID string `xml:"groupId"`
+		Version string `xml:"version"`
+		Parent  struct {
+			GroupID string `xml:"groupId"`
+			Version string `xml:"version"`
+		} `xml:"parent"`
+		Properties struct {
+			Entries []struct {
+				XMLName xml.Name
+				Value   string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"properties"`
+		Dependencies []pomDependency `xml:"dependencies>dependency"`
+		Managed      []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+	}
+	if err := xml.Unmarshal(data, &pom); err != nil {
+		return nil, err
+	}
+
+	properties := map[string]string{
+		"project.groupId":        cmp.Or(pom.GroupID, pom.Parent.GroupID),
+		"project.version":        cmp.Or(pom.Version, pom.Parent.Version),
+		"project.parent.version": pom.Parent.Version,
+	}
+	for _, p := range pom.Properties.Entries {
+		properties[p.XMLName.Local] = strings.TrimSpace(p.Value)
+	}
+	interpolate := func(s string) string {
+		return pomProperty.ReplaceAllStringFunc(strings.TrimSpace(s), func(ref string) string {
+			if value, ok := properties[ref[2:len(ref)-1]]; ok {
+				return value
+			}
+			return ref
+		})
+	}
+
+	managed := make(map[string]string)
+	for _, d := range pom.Managed {
+		managed[interpolate(d.GroupID)+":"+interpolate(d.ArtifactID)] = interpolate(d.Version)
+	}
+
+	var deps []Dependency
+	for _, d := range pom.Dependencies {
+		name := interpolate(d.GroupID) + ":" + interpolate(d.ArtifactID)
+		version := interpolate(d.Version)
+		if version == "" {
+			version = managed[name]
+		}
+		dep := Dependency{Ecosystem: "maven", Name: name, Requirements: version, Scope: Runtime}
+		// A plain version is a soft requirement, which Maven uses as is
+		// unless a range or a conflict says otherwise.
+		if version != "" && !strings.ContainsAny(version, "[](),$") {
+			dep.Version = version
+		}
+		switch strings.TrimSpace(d.Scope) {
+		case "test":
+			dep.Scope = Test
+		case "provided", "system":
+			dep.Scope = Build
+		}
+		if strings.TrimSpace(d.Optional) == "true" {
+			dep.Scope = Optional
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+var pomProperty = regexp.MustCompile(`\$\{[^}]+\}`)
+
+var pep508Name = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)\s*(\[[^\]]*\])?\s*(.*)$`)
+
+// ParsePEP508 splits a PEP 508 requirement such as
+// "requests[socks]>=2.31; python_version>'3.8'" into its project name and
+// version specifier. Environment markers and extras are dropped.
+func ParsePEP508(req string) (Dependency, bool) {
+	if i := strings.Index(req, ";"); i >= 0 {
+		req = req[:i]
+	}
+	m := pep508Name.FindStringSubmatch(req)
+	if m == nil {
+		return Dependency{}, false
+	}
+	spec := strings.TrimSpace(m[3])
+	if strings.HasPrefix(spec, "@") {
+		// Direct URL references carry no version constraint.
+		spec = ""
+	}
+	spec = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(spec, "("), ")"))
+	dep := Dependency{Ecosystem: "pypi", Name: m[1], Requirements: spec}
+	if strings.HasPrefix(spec, "==") && !strings.HasPrefix(spec, "===") {
+		dep.Version = pinnedVersion(spec, "==", pep440Version)
+	}
+	return dep, true
+}
+
+var (
+	// semverVersion matches full SemVer versions; npm and cargo read
+	// shorter ones such as "1.2" as ranges.
+	semverVersion = regexp.MustCompile(`^v?[0-9]+\.[0-9]+\.[0-9]+([-+][0-9A-Za-z.+-]+)?$`)
+	// pep440Version matches PEP 440 versions without wildcards.
+	pep440Version = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*([-_.+]?[A-Za-z][0-9A-Za-z.+-]*)?$`)
+)
+
+// pinnedVersion returns the version an exact requirement such as "1.2.3" or
+// "==1.2.3" pins, after the optional operator, or "" when exact does not
+// match it.
+func pinnedVersion(req, operator string, exact *regexp.Regexp) string {
+	version := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(req), operator))
+	if !exact.MatchString(version) {
+		return ""
+	}
+	return version
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
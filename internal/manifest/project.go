@@ -0,0 +1,117 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Load parses the manifests and lockfiles at path, a single file or a
+// directory whose top-level files are detected by name. It returns their
+// combined direct dependencies, see [Combine], and the files parsed.
+func Load(path string) ([]Dependency, []string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = nil
+		for _, entry := range entries {
+			if !entry.IsDir() && IsSupported(entry.Name()) {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+		if len(files) == 0 {
+			return nil, nil, fmt.Errorf("no supported manifest or lockfile found in %s", path)
+		}
+	}
+
+	var manifests, lockfiles []Dependency
+	for _, file := range files {
+		deps, err := ParseFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		if IsLockfile(file) {
+			lockfiles = append(lockfiles, deps...)
+		} else {
+			manifests = append(manifests, deps...)
+		}
+	}
+	return Combine(manifests, lockfiles), files, nil
+}
+
+// ParseFile parses the manifest or lockfile at path, detected by its name.
+func ParseFile(path string) ([]Dependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(filepath.Base(path), data)
+}
+
+// Combine merges the dependencies of manifests with those of the lockfiles
+// of the same project. Manifest dependencies take the version their
+// lockfile locks, which is the one installed even when the manifest pins
+// another, and the source it locks them from. For ecosystems without a manifest, the direct dependencies of the
+// lockfiles are kept as they are. Indirect dependencies are dropped.
+func Combine(manifests, lockfiles []Dependency) []Dependency {
+	locked := make(map[string]Dependency)
+	hasManifest := make(map[string]bool)
+	for _, dep := range lockfiles {
+		// Copies locked for other packages do not override the version in
+		// use by the project.
+		if _, ok := locked[dep.Key()]; !ok || !dep.Indirect {
+			locked[dep.Key()] = dep
+		}
+	}
+	for _, dep := range manifests {
+		hasManifest[dep.Ecosystem] = true
+	}
+
+	var deps []Dependency
+	seen := make(map[string]bool)
+	for _, dep := range manifests {
+		// A package listed by several manifests, e.g. requirements.txt and
+		// pyproject.toml, is reported once.
+		if dep.Indirect || seen[dep.Key()] {
+			continue
+		}
+		seen[dep.Key()] = true
+		if lock, ok := locked[dep.Key()]; ok {
+			if lock.Version != "" {
+				dep.Version = lock.Version
+			}
+			if dep.Source == "" {
+				dep.Source = lock.Source
+			}
+		}
+		deps = append(deps, dep)
+	}
+	for _, dep := range lockfiles {
+		if !dep.Indirect && !hasManifest[dep.Ecosystem] {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+var pypiSeparators = regexp.MustCompile(`[-_.]+`)
+
+// Key identifies the package of a dependency across manifests, lockfiles
+// and registries, normalizing PyPI names as PEP 503 does.
+func (d Dependency) Key() string {
+	name := d.Name
+	if d.Ecosystem == "pypi" {
+		name = pypiSeparators.ReplaceAllString(strings.ToLower(name), "-")
+	}
+	return d.Ecosystem + " " + name
+}
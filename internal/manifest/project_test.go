@@ -0,0 +1,69 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCombine(t *testing.T) {
+	manifests := []Dependency{
+		{Ecosystem: "pypi", Name: "Flask_Login", Requirements: ">=0.6", Scope: Runtime},
+		// Pinned by the manifest, but the lockfile locks another version.
+		{Ecosystem: "pypi", Name: "black", Requirements: "==24.3.0", Version: "24.3.0", Scope: Development},
+		{Ecosystem: "pypi", Name: "flask-login", Requirements: ">=0.5", Scope: Runtime},
+		{Ecosystem: "golang", Name: "golang.org/x/sys", Requirements: "v0.20.0", Version: "v0.20.0", Scope: Runtime, Indirect: true},
+	}
+	lockfiles := []Dependency{
+		{Ecosystem: "pypi", Name: "flask-login", Version: "0.6.3", Scope: Runtime},
+		{Ecosystem: "pypi", Name: "flask-login", Version: "0.4.1", Scope: Runtime, Indirect: true},
+		{Ecosystem: "pypi", Name: "black", Version: "24.4.0", Scope: Runtime},
+		{Ecosystem: "gem", Name: "rails", Requirements: "~> 7.1", Version: "7.1.3", Scope: Runtime},
+		{Ecosystem: "gem", Name: "racc", Version: "1.7.3", Scope: Runtime, Indirect: true},
+	}
+
+	want := []Dependency{
+		{Ecosystem: "pypi", Name: "Flask_Login", Requirements: ">=0.6", Version: "0.6.3", Scope: Runtime},
+		{Ecosystem: "pypi", Name: "black", Requirements: "==24.3.0", Version: "24.4.0", Scope: Development},
+		{Ecosystem: "gem", Name: "rails", Requirements: "~> 7.1", Version: "7.1.3", Scope: Runtime},
+	}
+	if got := Combine(manifests, lockfiles); !reflect.DeepEqual(got, want) {
+		t.Errorf("Combine() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"package.json": `{"dependencies": {"react": "^18.2.0", "left-pad": "1.2.0"}}`,
+		"package-lock.json": `{"packages": {"": {"dependencies": {"react": "^18.2.0", "left-pad": "1.3.0"}},
+			"node_modules/react": {"version": "18.2.0"}, "node_modules/left-pad": {"version": "1.3.0"}}}`,
+		"README.md": "# app",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deps, parsed, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	// The lockfile version is the one installed, whatever the manifest pins.
+	want := []Dependency{
+		{Ecosystem: "npm", Name: "left-pad", Requirements: "1.2.0", Version: "1.3.0", Scope: Runtime},
+		{Ecosystem: "npm", Name: "react", Requirements: "^18.2.0", Version: "18.2.0", Scope: Runtime},
+	}
+	if !reflect.DeepEqual(deps, want) {
+		t.Errorf("Load() = %+v, want %+v", deps, want)
+	}
+	if len(parsed) != 2 {
+		t.Errorf("Load() parsed %v, want the two manifests", parsed)
+	}
+
+	if _, _, err := Load(t.TempDir()); err == nil {
+		t.Error("expected error for a directory without manifests")
+	}
+}
@@ -0,0 +1,88 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/acidghost/a555pq/internal/advisory"
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/git-pkgs/registries"
+)
+
+// Advisories lists the advisories affecting a package and tells whether
+// they affect version, or the latest version when version is empty.
+func (c *Client) Advisories(ctx context.Context, name, version string, source advisory.Source) (*formatter.AdvisoriesOutput, error) {
+	if version == "" {
+		latest, err := c.Latest(ctx, name, Options{})
+		if err != nil {
+			return nil, err
+		}
+		version = latest.Version
+	}
+
+	vulns, ecosystem, err := c.vulnerabilities(ctx, name, source)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &formatter.AdvisoriesOutput{
+		Package:    name,
+		Version:    version,
+		Advisories: make([]formatter.AdvisoryItem, 0, len(vulns)),
+	}
+	for _, vuln := range vulns {
+		if vuln.Withdrawn != nil {
+			continue
+		}
+		item := formatter.AdvisoryItem{
+			ID:             vuln.ID,
+			Aliases:        vuln.Aliases,
+			Summary:        vuln.Summary,
+			Severity:       vuln.SeverityLabel(),
+			Affected:       vuln.AffectedRanges(ecosystem, name, c.ecosystem),
+			Patched:        vuln.FixedVersions(ecosystem, name),
+			AffectsVersion: vuln.Affects(ecosystem, name, version, c.ecosystem),
+		}
+		if vector, score, ok := vuln.CVSS(); vector != "" {
+			item.CVSSVector = vector
+			if ok {
+				item.CVSS = strconv.FormatFloat(score, 'f', 1, 64)
+			}
+		}
+		output.Advisories = append(output.Advisories, item)
+	}
+	return output, nil
+}
+
+// vulnerabilities fetches the advisories of a package from source, along
+// with the OSV name of the client's ecosystem.
+func (c *Client) vulnerabilities(ctx context.Context, name string, source advisory.Source) ([]advisory.Vulnerability, string, error) {
+	ecosystem, ok := advisory.OSVEcosystem(c.ecosystem)
+	if !ok {
+		return nil, "", fmt.Errorf("advisories are not available for %s", c.ecosystem)
+	}
+	vulns, err := source.Vulnerabilities(ctx, ecosystem, name)
+	if err != nil {
+		return nil, "", err
+	}
+	return vulns, ecosystem, nil
+}
+
+// filterVulnerable removes the versions affected by any of vulns.
+func (c *Client) filterVulnerable(versions []registries.Version, vulns []advisory.Vulnerability, ecosystem, name string) []registries.Version {
+	filtered := make([]registries.Version, 0, len(versions))
+	for _, v := range versions {
+		affected := false
+		for _, vuln := range vulns {
+			if vuln.Affects(ecosystem, name, v.Number, c.ecosystem) {
+				affected = true
+				break
+			}
+		}
+		if !affected {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
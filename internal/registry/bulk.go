@@ -0,0 +1,146 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/acidghost/a555pq/internal/container"
+	"github.com/acidghost/a555pq/internal/github"
+	"github.com/git-pkgs/registries"
+)
+
+// DefaultBulkConcurrency is the number of packages BulkLatest queries at
+// once unless told otherwise.
+const DefaultBulkConcurrency = 16
+
+// LatestResult is the outcome of a latest-version lookup for one package URL
+// of a bulk query. Exactly one of Version and Err is set.
+type LatestResult struct {
+	PURL    string
+	Version string
+	Err     error
+}
+
+// BulkLatest resolves the latest version of every package URL in purls,
+// across ecosystems, with up to concurrency lookups in flight. fn is called
+// with the results in input order, concurrency at a time, as soon as each
+// run of results is complete; lookups do not wait for one another. Each
+// package is resolved as Latest resolves it, so the same strategy picks the
+// same version. Errors specific to a package are reported in its
+// [LatestResult] and do not stop the batch; an error returned by fn does.
+//
+// Only MinReleaseAge and Strategy of opts apply, and neither to pkg:docker
+// images, resolved to their latest tag, nor to pkg:github repositories,
+// resolved to their latest release.
+func BulkLatest(ctx context.Context, purls []string, concurrency int, opts Options, fn func([]LatestResult) error) error {
+	b := &bulkResolver{
+		concurrency: concurrency,
+		opts:        Options{MinReleaseAge: opts.MinReleaseAge, Strategy: opts.Strategy},
+		latest:      latestFromPURL,
+	}
+	return b.run(ctx, purls, fn)
+}
+
+// latestFromPURL resolves the latest version of a single package URL.
+func latestFromPURL(ctx context.Context, purl string, opts Options) (string, error) {
+	p, err := registries.ParsePURL(purl)
+	if err != nil {
+		return "", fmt.Errorf("unsupported package URL '%s': %w", purl, err)
+	}
+	switch p.Type {
+	case "docker":
+		image, err := container.ImageFromPURL(p.WithoutVersion())
+		if err != nil {
+			return "", err
+		}
+		return container.NewClient().GetLatestTag(image)
+	case "github":
+		return github.NewClient(false).GetLatestVersion(p.FullName())
+	}
+
+	client, name, _, err := NewFromPURL(purl)
+	if err != nil {
+		return "", err
+	}
+	output, err := client.Latest(ctx, name, opts)
+	if err != nil {
+		return "", err
+	}
+	return output.Version, nil
+}
+
+type bulkResolver struct {
+	concurrency int
+	opts        Options
+	// latest resolves a single package URL, reporting why it failed.
+	latest func(ctx context.Context, purl string, opts Options) (string, error)
+}
+
+func (b *bulkResolver) run(ctx context.Context, purls []string, fn func([]LatestResult) error) error {
+	if b.concurrency <= 0 {
+		b.concurrency = DefaultBulkConcurrency
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Workers pick the next package URL as soon as they are done with one;
+	// done tells which result is in.
+	results := make([]LatestResult, len(purls))
+	jobs := make(chan int)
+	done := make(chan int)
+	var wg sync.WaitGroup
+	for range min(b.concurrency, len(purls)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				version, err := b.latest(ctx, purls[i], b.opts)
+				results[i] = LatestResult{PURL: purls[i], Version: version, Err: err}
+				done <- i
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for i := range purls {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// Results are passed on in input order: next is the first one fn has
+	// not seen, and complete the end of the run of results in after it.
+	var (
+		ready    = make([]bool, len(purls))
+		next     int
+		complete int
+		fnErr    error
+	)
+	for i := range done {
+		ready[i] = true
+		if fnErr != nil {
+			continue
+		}
+		for complete < len(purls) && ready[complete] {
+			complete++
+		}
+		for next < complete && (complete-next >= b.concurrency || complete == len(purls)) {
+			end := min(next+b.concurrency, complete)
+			if err := fn(results[next:end]); err != nil {
+				fnErr = err
+				cancel()
+				break
+			}
+			next = end
+		}
+	}
+	return fnErr
+}
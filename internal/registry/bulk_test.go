@@ -0,0 +1,164 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/git-pkgs/registries"
+	"github.com/git-pkgs/registries/client"
+)
+
+// agedRegistry serves versions published the given duration ago, or
+// undated ones for a zero age.
+type agedRegistry struct {
+	registries.Registry
+	versions map[string]map[string]time.Duration
+}
+
+func (r agedRegistry) FetchVersions(_ context.Context, name string) ([]registries.Version, error) {
+	ages, ok := r.versions[name]
+	if !ok {
+		return nil, client.ErrNotFound
+	}
+	var versions []registries.Version
+	for number, age := range ages {
+		v := registries.Version{Number: number}
+		if age > 0 {
+			v.PublishedAt = time.Now().Add(-age)
+		}
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
+
+func TestBulkLatest(t *testing.T) {
+	const day = 24 * time.Hour
+	reg := agedRegistry{versions: map[string]map[string]time.Duration{
+		"old":    {"1.0.0": 300 * day},
+		"fresh":  {"1.0.0": 100 * day, "1.9.0": time.Hour},
+		"patch":  {"2.0.0": 60 * day, "1.5.0": 30 * day},
+		"nodate": {"0.3.0": 0},
+	}}
+	latest := func(ctx context.Context, purl string, opts Options) (string, error) {
+		p, err := registries.ParsePURL(purl)
+		if err != nil {
+			return "", err
+		}
+		c := &Client{reg: reg, ecosystem: p.Type}
+		output, err := c.Latest(ctx, p.FullName(), opts)
+		if err != nil {
+			return "", err
+		}
+		return output.Version, nil
+	}
+	purls := []string{"pkg:npm/old", "pkg:npm/fresh", "pkg:npm/patch", "pkg:deno/nodate", "pkg:npm/missing"}
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{
+			name: "cooldown with the highest version",
+			opts: Options{MinReleaseAge: day, Strategy: StrategyHighest},
+			want: []string{"1.0.0", "1.0.0", "2.0.0", "0.3.0", ""},
+		},
+		{
+			name: "cooldown with the newest version",
+			opts: Options{MinReleaseAge: day, Strategy: StrategyNewest},
+			want: []string{"1.0.0", "1.0.0", "1.5.0", "0.3.0", ""},
+		},
+		{
+			name: "highest without cooldown",
+			opts: Options{Strategy: StrategyHighest},
+			want: []string{"1.0.0", "1.9.0", "2.0.0", "0.3.0", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &bulkResolver{concurrency: 2, opts: tt.opts, latest: latest}
+			var chunks [][]LatestResult
+			err := b.run(context.Background(), purls, func(results []LatestResult) error {
+				chunks = append(chunks, results)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("run() error = %v", err)
+			}
+
+			if len(chunks) != 3 {
+				t.Fatalf("got %d chunks, want 3", len(chunks))
+			}
+			var results []LatestResult
+			for _, c := range chunks {
+				results = append(results, c...)
+			}
+			for i, want := range tt.want {
+				r := results[i]
+				if r.PURL != purls[i] || r.Version != want || (r.Err != nil) != (want == "") {
+					t.Errorf("result %d = %+v, want %s %q", i, r, purls[i], want)
+				}
+			}
+		})
+	}
+}
+
+// TestBulkLatestNoBarrier checks that a slow lookup does not hold back the
+// lookups after it, while results are still passed on in input order.
+func TestBulkLatestNoBarrier(t *testing.T) {
+	third := make(chan struct{})
+	b := &bulkResolver{
+		concurrency: 2,
+		latest: func(_ context.Context, purl string, _ Options) (string, error) {
+			switch purl {
+			case "pkg:npm/a":
+				select {
+				case <-third:
+				case <-time.After(5 * time.Second):
+					return "", errors.New("the third lookup never started")
+				}
+			case "pkg:npm/c":
+				close(third)
+			}
+			return "1.0.0", nil
+		},
+	}
+
+	purls := []string{"pkg:npm/a", "pkg:npm/b", "pkg:npm/c", "pkg:npm/d"}
+	var got []string
+	err := b.run(context.Background(), purls, func(results []LatestResult) error {
+		for _, r := range results {
+			if r.Err != nil {
+				t.Errorf("%s: %v", r.PURL, r.Err)
+			}
+			got = append(got, r.PURL)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if !equal(got, purls) {
+		t.Errorf("results in order %v, want %v", got, purls)
+	}
+}
+
+func TestBulkLatestStopsOnError(t *testing.T) {
+	b := &bulkResolver{
+		concurrency: 1,
+		latest: func(context.Context, string, Options) (string, error) {
+			return "1.0.0", nil
+		},
+	}
+	calls := 0
+	stop := errors.New("stop")
+	err := b.run(context.Background(), []string{"pkg:npm/a", "pkg:npm/b", "pkg:npm/c"}, func([]LatestResult) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("run() = %v after %d calls, want %v after 1", err, calls, stop)
+	}
+}
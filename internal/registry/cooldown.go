@@ -0,0 +1,177 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/manifest"
+	"github.com/acidghost/a555pq/internal/timespan"
+	"github.com/git-pkgs/registries"
+)
+
+// DefaultCooldownConcurrency is the number of registry requests
+// CheckCooldown runs at once unless told otherwise.
+const DefaultCooldownConcurrency = 16
+
+// Allowlist lists the packages exempt from the cooldown check, as Package
+// URLs. A URL without a version exempts every version of its package.
+type Allowlist []*registries.PURL
+
+// ParseAllowlist parses an allowlist, one Package URL per entry.
+func ParseAllowlist(purls []string) (Allowlist, error) {
+	list := make(Allowlist, 0, len(purls))
+	for _, s := range purls {
+		p, err := registries.ParsePURL(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid package URL '%s': %w", s, err)
+		}
+		list = append(list, p)
+	}
+	return list, nil
+}
+
+// Allows reports whether the allowlist exempts the version a dependency is
+// locked to.
+func (a Allowlist) Allows(dep manifest.Dependency) bool {
+	for _, p := range a {
+		entry := manifest.Dependency{Ecosystem: p.Type, Name: p.FullName()}
+		if entry.Key() == dep.Key() && (p.Version == "" || p.Version == dep.Version) {
+			return true
+		}
+	}
+	return false
+}
+
+// CooldownOptions controls CheckCooldown.
+type CooldownOptions struct {
+	// MinReleaseAge is how long ago a locked version must have been
+	// published.
+	MinReleaseAge time.Duration
+	Allowlist     Allowlist
+	// AllowUndated passes the versions whose registry does not tell when
+	// they were published, which otherwise fail the check.
+	AllowUndated bool
+	// Concurrency bounds the registry requests running at once. Zero means
+	// DefaultCooldownConcurrency.
+	Concurrency int
+}
+
+// LockedFile is a lockfile and the package versions it locks.
+type LockedFile struct {
+	Path         string
+	Dependencies []manifest.Dependency
+}
+
+// CheckCooldown looks up when every package version locked by files was
+// published. It reports those younger than the minimum release age, those
+// without a publish time unless opts.AllowUndated is set, and those that
+// could not be looked up, marking the ones the allowlist exempts.
+// Dependencies without an exact version are skipped, and those locked from
+// elsewhere than the registry are reported as skipped.
+func CheckCooldown(ctx context.Context, files []LockedFile, opts CooldownOptions) *formatter.CooldownOutput {
+	return newCooldownChecker(opts, New).run(ctx, files, time.Now())
+}
+
+type cooldownChecker struct {
+	opts CooldownOptions
+	*versionFetcher
+}
+
+func newCooldownChecker(opts CooldownOptions, newClient func(string) (*Client, error)) *cooldownChecker {
+	return &cooldownChecker{opts: opts, versionFetcher: newVersionFetcher(newClient, opts.Concurrency, DefaultCooldownConcurrency)}
+}
+
+type cooldownResult struct {
+	finding *formatter.CooldownFinding
+	undated bool
+}
+
+func (o *cooldownChecker) run(ctx context.Context, files []LockedFile, now time.Time) *formatter.CooldownOutput {
+	output := &formatter.CooldownOutput{MinReleaseAge: timespan.Format(o.opts.MinReleaseAge)}
+
+	type job struct {
+		file string
+		dep  manifest.Dependency
+	}
+	var jobs []job
+	for _, file := range files {
+		output.Files = append(output.Files, file.Path)
+		seen := make(map[string]bool)
+		for _, dep := range file.Dependencies {
+			key := dep.Key() + "@" + dep.Version
+			if dep.Version == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			if dep.Source != "" {
+				output.Skipped = append(output.Skipped, formatter.SkippedPackage{
+					File: file.Path, Ecosystem: dep.Ecosystem, Name: dep.Name, Version: dep.Version, Source: dep.Source,
+				})
+				continue
+			}
+			jobs = append(jobs, job{file.Path, dep})
+		}
+	}
+
+	results := make([]cooldownResult, len(jobs))
+	var wg sync.WaitGroup
+	for i, j := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = o.check(ctx, j.file, j.dep, now)
+		}()
+	}
+	wg.Wait()
+
+	output.Checked = len(jobs)
+	for _, r := range results {
+		if r.undated {
+			output.Undated++
+		}
+		if r.finding != nil {
+			output.Findings = append(output.Findings, *r.finding)
+		}
+	}
+	return output
+}
+
+func (o *cooldownChecker) check(ctx context.Context, file string, dep manifest.Dependency, now time.Time) cooldownResult {
+	finding := &formatter.CooldownFinding{
+		File:      file,
+		Ecosystem: dep.Ecosystem,
+		Name:      dep.Name,
+		Version:   dep.Version,
+		Allowed:   o.opts.Allowlist.Allows(dep),
+	}
+
+	_, versions, err := o.fetch(ctx, dep.Ecosystem, dep.Name)
+	if err != nil {
+		finding.Error = err.Error()
+		return cooldownResult{finding: finding}
+	}
+	i := slices.IndexFunc(versions, func(v registries.Version) bool { return v.Number == dep.Version })
+	if i < 0 {
+		finding.Error = fmt.Sprintf("version '%s' of package '%s' not found", dep.Version, dep.Name)
+		return cooldownResult{finding: finding}
+	}
+
+	published := versions[i].PublishedAt
+	if published.IsZero() {
+		if o.opts.AllowUndated {
+			return cooldownResult{undated: true}
+		}
+		finding.Undated = true
+		return cooldownResult{finding: finding, undated: true}
+	}
+	if age := now.Sub(published); age < o.opts.MinReleaseAge {
+		finding.Published = published.Format("2006-01-02 15:04:05")
+		finding.Age = timespan.Format(age)
+		return cooldownResult{finding: finding}
+	}
+	return cooldownResult{}
+}
@@ -0,0 +1,88 @@
+package registry
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/manifest"
+	"github.com/git-pkgs/registries"
+)
+
+// undatedRegistry serves versions without publish times.
+type undatedRegistry struct {
+	registries.Registry
+}
+
+func (undatedRegistry) FetchVersions(context.Context, string) ([]registries.Version, error) {
+	return []registries.Version{{Number: "5.47.0"}}, nil
+}
+
+func TestCheckCooldown(t *testing.T) {
+	reg := datedRegistry{versions: map[string]map[string]int{
+		"react":      {"18.2.0": 800, "19.0.0": 2},
+		"left-pad":   {"1.3.0": 3000, "1.3.1": 1},
+		"flask-cors": {"4.0.1": 3},
+	}}
+	newClient := func(ecosystem string) (*Client, error) {
+		if ecosystem == "terraform" {
+			return &Client{reg: undatedRegistry{}, ecosystem: ecosystem}, nil
+		}
+		return &Client{reg: reg, ecosystem: ecosystem}, nil
+	}
+	allowlist, err := ParseAllowlist([]string{"pkg:npm/left-pad@1.3.1", "pkg:pypi/flask_cors"})
+	if err != nil {
+		t.Fatalf("ParseAllowlist() error = %v", err)
+	}
+	files := []LockedFile{
+		{Path: "package-lock.json", Dependencies: []manifest.Dependency{
+			{Ecosystem: "npm", Name: "react", Version: "19.0.0"},
+			{Ecosystem: "npm", Name: "react", Version: "18.2.0", Indirect: true},
+			{Ecosystem: "npm", Name: "react", Version: "19.0.0", Indirect: true},
+			{Ecosystem: "npm", Name: "left-pad", Version: "1.3.1"},
+			{Ecosystem: "npm", Name: "missing", Version: "1.0.0"},
+			{Ecosystem: "npm", Name: "linked"},
+		}},
+		{Path: "uv.lock", Dependencies: []manifest.Dependency{
+			{Ecosystem: "pypi", Name: "flask-cors", Version: "4.0.1"},
+			{Ecosystem: "pypi", Name: "flask-cors", Version: "4.0.0"},
+			{Ecosystem: "pypi", Name: "forked", Version: "1.0.0", Source: "git+https://github.com/org/forked#0123abcd"},
+		}},
+		{Path: ".terraform.lock.hcl", Dependencies: []manifest.Dependency{
+			{Ecosystem: "terraform", Name: "hashicorp/aws", Version: "5.47.0"},
+		}},
+	}
+	opts := CooldownOptions{MinReleaseAge: 7 * 24 * time.Hour, Allowlist: allowlist}
+
+	got := newCooldownChecker(opts, newClient).run(context.Background(), files, outdatedNow)
+	want := &formatter.CooldownOutput{
+		MinReleaseAge: "7d",
+		Files:         []string{"package-lock.json", "uv.lock", ".terraform.lock.hcl"},
+		Checked:       7,
+		Undated:       1,
+		Skipped: []formatter.SkippedPackage{
+			{File: "uv.lock", Ecosystem: "pypi", Name: "forked", Version: "1.0.0", Source: "git+https://github.com/org/forked#0123abcd"},
+		},
+		Findings: []formatter.CooldownFinding{
+			{File: "package-lock.json", Ecosystem: "npm", Name: "react", Version: "19.0.0", Published: "2024-12-30 00:00:00", Age: "2d"},
+			{File: "package-lock.json", Ecosystem: "npm", Name: "left-pad", Version: "1.3.1", Published: "2024-12-31 00:00:00", Age: "1d", Allowed: true},
+			{File: "package-lock.json", Ecosystem: "npm", Name: "missing", Version: "1.0.0", Error: "package 'missing' not found"},
+			{File: "uv.lock", Ecosystem: "pypi", Name: "flask-cors", Version: "4.0.1", Published: "2024-12-29 00:00:00", Age: "3d", Allowed: true},
+			{File: "uv.lock", Ecosystem: "pypi", Name: "flask-cors", Version: "4.0.0", Error: "version '4.0.0' of package 'flask-cors' not found", Allowed: true},
+			{File: ".terraform.lock.hcl", Ecosystem: "terraform", Name: "hashicorp/aws", Version: "5.47.0", Undated: true},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckCooldown() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	// Undated versions pass only when allowed to.
+	opts.AllowUndated = true
+	got = newCooldownChecker(opts, newClient).run(context.Background(), files, outdatedNow)
+	want.Findings = want.Findings[:len(want.Findings)-1]
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckCooldown() with AllowUndated =\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -0,0 +1,164 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/git-pkgs/registries"
+)
+
+// Dependency changes reported by DepsDiff.
+const (
+	DepAdded   = "added"
+	DepRemoved = "removed"
+	DepChanged = "changed"
+)
+
+// scopeOrder lists the well-known scopes first; others, such as PyPI
+// environment markers, sort after them by name.
+var scopeOrder = []registries.Scope{
+	registries.Runtime,
+	registries.Development,
+	registries.Test,
+	registries.Build,
+	registries.Optional,
+}
+
+// DepsDiff compares the dependencies of two versions of a package, scope by
+// scope, reporting added and removed dependencies and changes to their
+// requirements or optional flag.
+func (c *Client) DepsDiff(ctx context.Context, name, from, to string) (*formatter.DepsDiffOutput, error) {
+	output := &formatter.DepsDiffOutput{Package: name, From: from, To: to}
+
+	// Registries that cannot list versions still have dependencies to
+	// compare. Those that can are checked first, as some answer dependency
+	// lookups for unknown versions with an empty list.
+	if versions, err := c.reg.FetchVersions(ctx, name); err == nil && len(versions) > 0 {
+		known := make(map[string]bool, len(versions))
+		for _, v := range versions {
+			known[v.Number] = true
+			if v.PublishedAt.IsZero() {
+				continue
+			}
+			switch v.Number {
+			case from:
+				output.FromDate = v.PublishedAt.Format("2006-01-02 15:04:05")
+			case to:
+				output.ToDate = v.PublishedAt.Format("2006-01-02 15:04:05")
+			}
+		}
+		for _, version := range []string{from, to} {
+			if !known[version] {
+				return nil, fmt.Errorf("version '%s' of package '%s' not found", version, name)
+			}
+		}
+	}
+
+	before, err := c.reg.FetchDependencies(ctx, name, from)
+	if err != nil {
+		return nil, mapVersionError(name, from, err)
+	}
+	after, err := c.reg.FetchDependencies(ctx, name, to)
+	if err != nil {
+		return nil, mapVersionError(name, to, err)
+	}
+
+	output.Changes = diffDependencies(before, after)
+	return output, nil
+}
+
+// mapVersionError reports a missing version rather than a missing package
+// when the registry cannot find it.
+func mapVersionError(name, version string, err error) error {
+	if isNotFound(err) {
+		return fmt.Errorf("version '%s' of package '%s' not found", version, name)
+	}
+	return err
+}
+
+type depKey struct {
+	scope registries.Scope
+	name  string
+}
+
+// indexDependencies keys dependencies by scope and name. Dependencies
+// listed more than once in a scope, e.g. per target framework, have their
+// requirements joined and are optional if any listing is.
+func indexDependencies(deps []registries.Dependency) map[depKey]registries.Dependency {
+	index := make(map[depKey]registries.Dependency, len(deps))
+	for _, dep := range deps {
+		key := depKey{dep.Scope, dep.Name}
+		if prev, ok := index[key]; ok {
+			if prev.Requirements != dep.Requirements {
+				dep.Requirements = prev.Requirements + ", " + dep.Requirements
+			}
+			dep.Optional = prev.Optional || dep.Optional
+		}
+		index[key] = dep
+	}
+	return index
+}
+
+func diffDependencies(before, after []registries.Dependency) []formatter.DepChangeItem {
+	old, updated := indexDependencies(before), indexDependencies(after)
+
+	changes := []formatter.DepChangeItem{}
+	for key, dep := range updated {
+		prev, ok := old[key]
+		switch {
+		case !ok:
+			changes = append(changes, formatter.DepChangeItem{
+				Scope:      string(key.scope),
+				Name:       key.name,
+				Change:     DepAdded,
+				To:         dep.Requirements,
+				ToOptional: dep.Optional,
+			})
+		case prev.Requirements != dep.Requirements || prev.Optional != dep.Optional:
+			changes = append(changes, formatter.DepChangeItem{
+				Scope:        string(key.scope),
+				Name:         key.name,
+				Change:       DepChanged,
+				From:         prev.Requirements,
+				To:           dep.Requirements,
+				FromOptional: prev.Optional,
+				ToOptional:   dep.Optional,
+			})
+		}
+	}
+	for key, dep := range old {
+		if _, ok := updated[key]; !ok {
+			changes = append(changes, formatter.DepChangeItem{
+				Scope:        string(key.scope),
+				Name:         key.name,
+				Change:       DepRemoved,
+				From:         dep.Requirements,
+				FromOptional: dep.Optional,
+			})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b formatter.DepChangeItem) int {
+		if c := compareScopes(registries.Scope(a.Scope), registries.Scope(b.Scope)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return changes
+}
+
+func compareScopes(a, b registries.Scope) int {
+	rank := func(s registries.Scope) int {
+		if i := slices.Index(scopeOrder, s); i >= 0 {
+			return i
+		}
+		return len(scopeOrder)
+	}
+	if c := rank(a) - rank(b); c != 0 {
+		return c
+	}
+	return strings.Compare(string(a), string(b))
+}
@@ -0,0 +1,84 @@
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/git-pkgs/registries"
+)
+
+func TestDiffDependencies(t *testing.T) {
+	before := []registries.Dependency{
+		dep("express", "^4.18.0"),
+		dep("lodash", "^4.17.0"),
+		{Name: "fsevents", Requirements: "^2.3.0", Scope: registries.Optional, Optional: true},
+		{Name: "jest", Requirements: "^29.0.0", Scope: registries.Development},
+		dep("debug", "^4.3.0"),
+	}
+	after := []registries.Dependency{
+		dep("express", "^5.0.0"),
+		dep("zod", "^3.0.0"),
+		{Name: "fsevents", Requirements: "^2.3.0", Scope: registries.Optional},
+		{Name: "jest", Requirements: "^29.0.0", Scope: registries.Development},
+		{Name: "vitest", Requirements: "^1.0.0", Scope: registries.Development},
+		dep("debug", "^4.3.0"),
+	}
+
+	want := []formatter.DepChangeItem{
+		{Scope: "runtime", Name: "express", Change: DepChanged, From: "^4.18.0", To: "^5.0.0"},
+		{Scope: "runtime", Name: "lodash", Change: DepRemoved, From: "^4.17.0"},
+		{Scope: "runtime", Name: "zod", Change: DepAdded, To: "^3.0.0"},
+		{Scope: "development", Name: "vitest", Change: DepAdded, To: "^1.0.0"},
+		{Scope: "optional", Name: "fsevents", Change: DepChanged, From: "^2.3.0", To: "^2.3.0", FromOptional: true},
+	}
+
+	got := diffDependencies(before, after)
+	if len(got) != len(want) {
+		t.Fatalf("got %d changes %+v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDepsDiff(t *testing.T) {
+	reg := newFakeTreeRegistry()
+	reg.deps["left@2.0.0"] = []registries.Dependency{dep("shared", "^1.3.0"), dep("right", "^1.0.0")}
+	c := &Client{reg: reg, ecosystem: "npm"}
+
+	output, err := c.DepsDiff(context.Background(), "left", "1.1.0", "2.0.0")
+	if err != nil {
+		t.Fatalf("DepsDiff() error = %v", err)
+	}
+	if output.FromDate == "" || output.ToDate == "" {
+		t.Errorf("expected release dates, got %q and %q", output.FromDate, output.ToDate)
+	}
+	if len(output.Changes) != 2 || output.Changes[0].Name != "right" || output.Changes[1].Change != DepChanged {
+		t.Errorf("unexpected changes %+v", output.Changes)
+	}
+
+	if _, err := c.DepsDiff(context.Background(), "left", "1.1.0", "9.9.9"); err == nil || err.Error() != "version '9.9.9' of package 'left' not found" {
+		t.Errorf("DepsDiff() with an unknown version error = %v", err)
+	}
+}
+
+func TestIndexDependenciesOptional(t *testing.T) {
+	deps := []registries.Dependency{
+		dep("tslib", "^2.0.0"),
+		{Name: "tslib", Requirements: "^2.0.0", Scope: registries.Runtime, Optional: true},
+		{Name: "rxjs", Requirements: "^7.0.0", Scope: registries.Runtime, Optional: true},
+		dep("rxjs", "^6.0.0"),
+	}
+	index := indexDependencies(deps)
+	for _, name := range []string{"tslib", "rxjs"} {
+		if got := index[depKey{registries.Runtime, name}]; !got.Optional {
+			t.Errorf("%s = %+v, want optional", name, got)
+		}
+	}
+	if got := index[depKey{registries.Runtime, "tslib"}].Requirements; got != "^2.0.0" {
+		t.Errorf("tslib requirements = %q, want ^2.0.0", got)
+	}
+}
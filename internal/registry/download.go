@@ -0,0 +1,410 @@
+package registry
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // conda publishes md5 hashes of older packages
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/git-pkgs/registries"
+	"github.com/git-pkgs/registries/client"
+)
+
+// pypiRegistryURL is the index PyPI release files are listed by unless the
+// client queries another.
+const pypiRegistryURL = "https://pypi.org"
+
+// DownloadOptions controls which artifact Download fetches and where it is
+// written.
+type DownloadOptions struct {
+	Options
+	// Dir is the directory the artifact is written to, created if missing.
+	// The working directory when empty.
+	Dir string
+	// Sdist selects the source distribution of a PyPI release.
+	Sdist bool
+	// Wheel selects the PyPI wheel with this platform tag, e.g.
+	// "manylinux_2_17_x86_64" or "any", or with this full
+	// "python-abi-platform" tag.
+	Wheel string
+	// AllowUnverified writes artifacts whose registry publishes no
+	// integrity, or only a weak one, which are otherwise refused.
+	AllowUnverified bool
+}
+
+// integrityHashes are the hashes of the algorithms integrity strings name.
+// sha1 is only published by older npm versions and by packagist, and md5 by
+// conda for older packages; see weakIntegrities.
+var integrityHashes = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// weakIntegrities are the algorithms whose hashes are still checked, but no
+// longer trusted to verify an artifact.
+var weakIntegrities = map[string]bool{"md5": true, "sha1": true}
+
+// errUnsupportedIntegrity is returned by parseIntegrity for algorithms
+// outside integrityHashes. Such integrities cannot verify an artifact.
+var errUnsupportedIntegrity = errors.New("unsupported integrity")
+
+// downloadClient fetches artifacts, which may be large, with a timeout
+// generous enough for them.
+var downloadClient = &http.Client{Timeout: 10 * time.Minute}
+
+// artifact is a file a version is distributed as.
+type artifact struct {
+	url  string
+	file string
+	// integrity is the hash the registry publishes for the file as an SRI
+	// string, empty when it publishes none.
+	integrity string
+}
+
+// Download fetches the artifact of a version from the registry and checks
+// it against the integrity the registry publishes, writing it to opts.Dir
+// only when it matches. Artifacts without an integrity, or with only a weak
+// sha1 one, are written only when opts.AllowUnverified is set. The version
+// is opts.Version, or the registry's latest. PyPI releases are downloaded as
+// their source distribution unless a wheel is selected, falling back to a
+// pure Python wheel.
+func (c *Client) Download(ctx context.Context, name string, opts DownloadOptions) (*formatter.DownloadOutput, error) {
+	if (opts.Sdist || opts.Wheel != "") && c.ecosystem != "pypi" {
+		return nil, fmt.Errorf("sdist and wheel selection only applies to pypi")
+	}
+
+	pkg, err := c.reg.FetchPackage(ctx, name)
+	if err != nil {
+		return nil, c.mapError(name, err)
+	}
+	versions, err := c.reg.FetchVersions(ctx, name)
+	if err != nil {
+		return nil, c.mapError(name, err)
+	}
+	number, err := c.showVersion(pkg, versions, true, opts.Options)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(versions, func(v registries.Version) bool { return v.Number == number })
+	if i < 0 {
+		return nil, fmt.Errorf("version '%s' of package '%s' not found", number, name)
+	}
+
+	var art artifact
+	if c.ecosystem == "pypi" {
+		art, err = c.pypiArtifact(ctx, name, number, opts)
+	} else {
+		art, err = c.artifact(name, versions[i])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := fetchArtifact(ctx, art, opts.Dir, opts.AllowUnverified)
+	if err != nil {
+		return nil, err
+	}
+	output.Package = name
+	output.Version = number
+	return output, nil
+}
+
+// artifact locates the file a version is distributed as, preferring the
+// location the registry lists for the version over the conventional one.
+func (c *Client) artifact(name string, v registries.Version) (artifact, error) {
+	var location string
+	for _, key := range []string{"tarball", "dist_url"} {
+		if s, _ := v.Metadata[key].(string); s != "" {
+			location = s
+			break
+		}
+	}
+	if location == "" {
+		location = c.reg.URLs().Download(name, v.Number)
+	}
+	if location == "" {
+		return artifact{}, fmt.Errorf("no download available for version '%s' of package '%s'", v.Number, name)
+	}
+
+	file, err := artifactFile(name, v.Number, location)
+	if err != nil {
+		return artifact{}, err
+	}
+	integrity := v.Integrity
+	if c.ecosystem == "julia" {
+		// Julia publishes the git tree hash of a version, not a hash of the
+		// file it is distributed as.
+		integrity = ""
+	}
+	return artifact{url: location, file: file, integrity: integrity}, nil
+}
+
+// artifactFile names the file an artifact is written to after the last
+// element of its URL, prefixed with the package name when that is only the
+// version, as for Go module zips.
+func artifactFile(name, version, location string) (string, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("invalid download URL '%s': %w", location, err)
+	}
+	file := path.Base(u.Path)
+	if file == "/" || file == "." {
+		return "", fmt.Errorf("download URL '%s' does not name a file", location)
+	}
+	if strings.HasPrefix(file, version) || strings.HasPrefix(file, "v"+version) {
+		file = path.Base(name) + "-" + file
+	}
+	return file, nil
+}
+
+func (c *Client) pypiURL() string {
+	if c.baseURL != "" {
+		return c.baseURL
+	}
+	return pypiRegistryURL
+}
+
+// pypiFile is a file of a PyPI release, as listed by the JSON API.
+type pypiFile struct {
+	Filename    string            `json:"filename"`
+	PackageType string            `json:"packagetype"`
+	URL         string            `json:"url"`
+	Digests     map[string]string `json:"digests"`
+}
+
+// pypiArtifact picks the file of a PyPI release to download among those the
+// release lists, as selected by opts.
+func (c *Client) pypiArtifact(ctx context.Context, name, version string, opts DownloadOptions) (artifact, error) {
+	var resp struct {
+		URLs []pypiFile `json:"urls"`
+	}
+	endpoint := fmt.Sprintf("%s/pypi/%s/%s/json", c.pypiURL(), url.PathEscape(name), url.PathEscape(version))
+	if err := client.DefaultClient().GetJSON(ctx, endpoint, &resp); err != nil {
+		return artifact{}, fmt.Errorf("failed to list files of version '%s' of package '%s': %w", version, name, err)
+	}
+
+	var sdist *pypiFile
+	var wheels []pypiFile
+	for i, f := range resp.URLs {
+		switch f.PackageType {
+		case "sdist":
+			if sdist == nil {
+				sdist = &resp.URLs[i]
+			}
+		case "bdist_wheel":
+			wheels = append(wheels, f)
+		}
+	}
+
+	var file *pypiFile
+	switch {
+	case opts.Wheel != "":
+		var matches []pypiFile
+		for _, w := range wheels {
+			if wheelMatches(w.Filename, opts.Wheel) {
+				matches = append(matches, w)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return artifact{}, fmt.Errorf("no wheel of version '%s' of package '%s' for '%s', available: %s",
+				version, name, opts.Wheel, wheelList(wheels))
+		case 1:
+			file = &matches[0]
+		default:
+			return artifact{}, fmt.Errorf("%d wheels of version '%s' of package '%s' match '%s', give a full tag: %s",
+				len(matches), version, name, opts.Wheel, wheelList(matches))
+		}
+	case sdist != nil:
+		file = sdist
+	case opts.Sdist:
+		return artifact{}, fmt.Errorf("no source distribution of version '%s' of package '%s'", version, name)
+	default:
+		for i, w := range wheels {
+			if wheelMatches(w.Filename, "any") {
+				file = &wheels[i]
+				break
+			}
+		}
+		if file == nil {
+			return artifact{}, fmt.Errorf("no source distribution or pure Python wheel of version '%s' of package '%s', available: %s",
+				version, name, wheelList(wheels))
+		}
+	}
+
+	art := artifact{url: file.URL, file: file.Filename}
+	if digest := file.Digests["sha256"]; digest != "" {
+		art.integrity = "sha256-" + digest
+	}
+	return art, nil
+}
+
+// wheelTag is the "python-abi-platform" tag of a wheel, from its filename.
+func wheelTag(filename string) string {
+	parts := strings.Split(strings.TrimSuffix(filename, ".whl"), "-")
+	if len(parts) < 5 {
+		return ""
+	}
+	return strings.Join(parts[len(parts)-3:], "-")
+}
+
+// wheelMatches tells whether a wheel has the given full tag, or the given
+// platform tag among the compressed platform tags of its filename.
+func wheelMatches(filename, tag string) bool {
+	full := wheelTag(filename)
+	if full == "" {
+		return false
+	}
+	if full == tag {
+		return true
+	}
+	platforms := full[strings.LastIndex(full, "-")+1:]
+	return slices.Contains(strings.Split(platforms, "."), tag)
+}
+
+func wheelList(wheels []pypiFile) string {
+	if len(wheels) == 0 {
+		return "none"
+	}
+	tags := make([]string, 0, len(wheels))
+	for _, w := range wheels {
+		tags = append(tags, wheelTag(w.Filename))
+	}
+	return strings.Join(tags, ", ")
+}
+
+// parseIntegrity splits an SRI string such as "sha512-<base64>" into its
+// algorithm and digest. Registries that publish hex digests in SRI form,
+// such as "sha256-<hex>", are accepted too, and reported hex tells so.
+func parseIntegrity(integrity string) (algorithm string, digest []byte, hexDigest bool, err error) {
+	algorithm, value, ok := strings.Cut(integrity, "-")
+	newHash, known := integrityHashes[algorithm]
+	if !ok || !known {
+		return "", nil, false, fmt.Errorf("%w '%s'", errUnsupportedIntegrity, integrity)
+	}
+	size := newHash().Size()
+	if len(value) == 2*size {
+		if digest, err := hex.DecodeString(value); err == nil {
+			return algorithm, digest, true, nil
+		}
+	}
+	digest, err = base64.StdEncoding.DecodeString(value)
+	if err != nil || len(digest) != size {
+		return "", nil, false, fmt.Errorf("malformed integrity '%s'", integrity)
+	}
+	return algorithm, digest, false, nil
+}
+
+// formatIntegrity writes a digest as an SRI string, in hex when the
+// registry writes it so, for the two to be compared at a glance.
+func formatIntegrity(algorithm string, digest []byte, hexDigest bool) string {
+	if hexDigest {
+		return algorithm + "-" + hex.EncodeToString(digest)
+	}
+	return algorithm + "-" + base64.StdEncoding.EncodeToString(digest)
+}
+
+// fetchArtifact downloads an artifact next to its destination in dir, and
+// moves it into place once its hash matches the published integrity. The
+// hash is sha256 when the registry publishes none, or one of an unsupported
+// algorithm. Unless allowUnverified is set, artifacts that cannot be
+// verified, for lack of a supported integrity or with a weak one, are not
+// written either.
+func fetchArtifact(ctx context.Context, art artifact, dir string, allowUnverified bool) (*formatter.DownloadOutput, error) {
+	algorithm, hexDigest := "sha256", false
+	var expected []byte
+	if art.integrity != "" {
+		a, digest, h, err := parseIntegrity(art.integrity)
+		switch {
+		case errors.Is(err, errUnsupportedIntegrity):
+		case err != nil:
+			return nil, err
+		default:
+			algorithm, expected, hexDigest = a, digest, h
+		}
+	}
+
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", art.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := downloadClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", art.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: unexpected status code %d", art.url, resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+art.file+".*.part")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	h := integrityHashes[algorithm]()
+	_, err = io.Copy(io.MultiWriter(tmp, h), resp.Body)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", art.url, err)
+	}
+
+	digest := h.Sum(nil)
+	computed := formatIntegrity(algorithm, digest, hexDigest)
+	if expected != nil && !slices.Equal(digest, expected) {
+		return nil, fmt.Errorf("integrity mismatch for %s: expected %s, computed %s", art.file, art.integrity, computed)
+	}
+	weak := weakIntegrities[algorithm]
+	switch {
+	case allowUnverified:
+	case expected == nil && art.integrity != "":
+		return nil, fmt.Errorf("refusing to write %s: the registry only publishes an unsupported integrity %s for it, computed %s",
+			art.file, art.integrity, computed)
+	case expected == nil:
+		return nil, fmt.Errorf("refusing to write %s: the registry publishes no integrity for it, computed %s", art.file, computed)
+	case weak:
+		return nil, fmt.Errorf("refusing to write %s: the registry only publishes a weak %s integrity for it, computed %s",
+			art.file, algorithm, computed)
+	}
+
+	file := filepath.Join(dir, art.file)
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return &formatter.DownloadOutput{
+		File:      file,
+		URL:       art.url,
+		Integrity: art.integrity,
+		Computed:  computed,
+		Verified:  expected != nil && !weak,
+		Weak:      weak,
+	}, nil
+}
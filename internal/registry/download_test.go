@@ -0,0 +1,280 @@
+package registry
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/git-pkgs/registries"
+)
+
+// newDownloadServer stands in for the npm registry and PyPI, serving
+// artifacts along with their integrity, which for left-pad 1.2.0 does not
+// match. left-pad 1.0.0 only has a sha1 shasum, 1.1.0 no hash at all, 1.4.0
+// an md5 one and 1.5.0 one of an algorithm left unsupported.
+func newDownloadServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(nil)
+	t.Cleanup(srv.Close)
+
+	sha512sum := func(body string) string {
+		sum := sha512.Sum512([]byte(body))
+		return "sha512-" + base64.StdEncoding.EncodeToString(sum[:])
+	}
+	sha1sum := func(body string) string {
+		sum := sha1.Sum([]byte(body))
+		return hex.EncodeToString(sum[:])
+	}
+	md5sum := func(body string) string {
+		sum := md5.Sum([]byte(body))
+		return "md5-" + base64.StdEncoding.EncodeToString(sum[:])
+	}
+	sha256sum := func(body string) string {
+		sum := sha256.Sum256([]byte(body))
+		return hex.EncodeToString(sum[:])
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/left-pad", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"name":      "left-pad",
+			"dist-tags": map[string]string{"latest": "1.3.0"},
+			"versions": map[string]any{
+				"1.0.0": map[string]any{"name": "left-pad", "version": "1.0.0", "dist": map[string]any{
+					"shasum":  sha1sum("left-pad 1.0.0"),
+					"tarball": srv.URL + "/left-pad/-/left-pad-1.0.0.tgz",
+				}},
+				"1.1.0": map[string]any{"name": "left-pad", "version": "1.1.0", "dist": map[string]any{
+					"tarball": srv.URL + "/left-pad/-/left-pad-1.1.0.tgz",
+				}},
+				"1.2.0": map[string]any{"name": "left-pad", "version": "1.2.0", "dist": map[string]any{
+					"integrity": sha512sum("left-pad 1.2.0 as published"),
+					"tarball":   srv.URL + "/left-pad/-/left-pad-1.2.0.tgz",
+				}},
+				"1.3.0": map[string]any{"name": "left-pad", "version": "1.3.0", "dist": map[string]any{
+					"integrity": sha512sum("left-pad 1.3.0"),
+					"tarball":   srv.URL + "/left-pad/-/left-pad-1.3.0.tgz",
+				}},
+				"1.4.0": map[string]any{"name": "left-pad", "version": "1.4.0", "dist": map[string]any{
+					"integrity": md5sum("left-pad 1.4.0"),
+					"tarball":   srv.URL + "/left-pad/-/left-pad-1.4.0.tgz",
+				}},
+				"1.5.0": map[string]any{"name": "left-pad", "version": "1.5.0", "dist": map[string]any{
+					"integrity": "blake2b-AAAA",
+					"tarball":   srv.URL + "/left-pad/-/left-pad-1.5.0.tgz",
+				}},
+			},
+		})
+	})
+	for _, version := range []string{"1.0.0", "1.1.0", "1.4.0", "1.5.0"} {
+		mux.HandleFunc("/left-pad/-/left-pad-"+version+".tgz", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("left-pad " + version))
+		})
+	}
+	mux.HandleFunc("/left-pad/-/left-pad-1.2.0.tgz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("left-pad 1.2.0 tampered with"))
+	})
+	mux.HandleFunc("/left-pad/-/left-pad-1.3.0.tgz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("left-pad 1.3.0"))
+	})
+
+	files := map[string]string{
+		"wheelhouse-1.0.tar.gz": "sdist",
+		"wheelhouse-1.0-cp312-cp312-manylinux_2_17_x86_64.manylinux2014_x86_64.whl": "bdist_wheel",
+		"wheelhouse-1.0-cp312-cp312-win_amd64.whl":                                  "bdist_wheel",
+		"wheelhouse-1.0-cp311-cp311-win_amd64.whl":                                  "bdist_wheel",
+	}
+	var urls []any
+	for file, packageType := range files {
+		urls = append(urls, map[string]any{
+			"filename":    file,
+			"packagetype": packageType,
+			"url":         srv.URL + "/files/" + file,
+			"digests":     map[string]string{"sha256": sha256sum(file)},
+		})
+		mux.HandleFunc("/files/"+file, func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(file))
+		})
+	}
+	mux.HandleFunc("/pypi/wheelhouse/json", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"info":     map[string]any{"name": "wheelhouse", "version": "1.0"},
+			"releases": map[string]any{"1.0": urls},
+		})
+	})
+	mux.HandleFunc("/pypi/wheelhouse/1.0/json", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"urls": urls})
+	})
+	srv.Config.Handler = mux
+	return srv
+}
+
+func newDownloadTestClient(t *testing.T, srv *httptest.Server, ecosystem string) *Client {
+	t.Helper()
+	reg, err := registries.New(ecosystem, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Client{reg: reg, ecosystem: ecosystem, baseURL: srv.URL}
+}
+
+func TestDownload(t *testing.T) {
+	srv := newDownloadServer(t)
+	npm := newDownloadTestClient(t, srv, "npm")
+	pypi := newDownloadTestClient(t, srv, "pypi")
+
+	tests := []struct {
+		name    string
+		client  *Client
+		pkg     string
+		opts    DownloadOptions
+		want    string
+		wantErr string
+		// unverified marks artifacts written without verification.
+		unverified bool
+	}{
+		{name: "npm latest", client: npm, pkg: "left-pad", want: "left-pad-1.3.0.tgz"},
+		{name: "npm mismatch", client: npm, pkg: "left-pad", opts: DownloadOptions{Options: Options{Version: "1.2.0"}},
+			wantErr: "integrity mismatch for left-pad-1.2.0.tgz"},
+		{name: "npm weak", client: npm, pkg: "left-pad", opts: DownloadOptions{Options: Options{Version: "1.0.0"}},
+			wantErr: "refusing to write left-pad-1.0.0.tgz: the registry only publishes a weak sha1 integrity"},
+		{name: "npm weak allowed", client: npm, pkg: "left-pad", opts: DownloadOptions{Options: Options{Version: "1.0.0"}, AllowUnverified: true},
+			want: "left-pad-1.0.0.tgz", unverified: true},
+		{name: "npm no integrity", client: npm, pkg: "left-pad", opts: DownloadOptions{Options: Options{Version: "1.1.0"}},
+			wantErr: "refusing to write left-pad-1.1.0.tgz: the registry publishes no integrity for it, computed sha256-"},
+		{name: "npm no integrity allowed", client: npm, pkg: "left-pad", opts: DownloadOptions{Options: Options{Version: "1.1.0"}, AllowUnverified: true},
+			want: "left-pad-1.1.0.tgz", unverified: true},
+		{name: "npm md5", client: npm, pkg: "left-pad", opts: DownloadOptions{Options: Options{Version: "1.4.0"}},
+			wantErr: "refusing to write left-pad-1.4.0.tgz: the registry only publishes a weak md5 integrity"},
+		{name: "npm md5 allowed", client: npm, pkg: "left-pad", opts: DownloadOptions{Options: Options{Version: "1.4.0"}, AllowUnverified: true},
+			want: "left-pad-1.4.0.tgz", unverified: true},
+		{name: "npm unsupported integrity", client: npm, pkg: "left-pad", opts: DownloadOptions{Options: Options{Version: "1.5.0"}},
+			wantErr: "refusing to write left-pad-1.5.0.tgz: the registry only publishes an unsupported integrity blake2b-AAAA"},
+		{name: "npm unsupported integrity allowed", client: npm, pkg: "left-pad", opts: DownloadOptions{Options: Options{Version: "1.5.0"}, AllowUnverified: true},
+			want: "left-pad-1.5.0.tgz", unverified: true},
+		{name: "npm wheel", client: npm, pkg: "left-pad", opts: DownloadOptions{Wheel: "any"},
+			wantErr: "sdist and wheel selection only applies to pypi"},
+		{name: "pypi default", client: pypi, pkg: "wheelhouse", want: "wheelhouse-1.0.tar.gz"},
+		{name: "pypi sdist", client: pypi, pkg: "wheelhouse", opts: DownloadOptions{Sdist: true}, want: "wheelhouse-1.0.tar.gz"},
+		{name: "pypi compressed platform tag", client: pypi, pkg: "wheelhouse", opts: DownloadOptions{Wheel: "manylinux2014_x86_64"},
+			want: "wheelhouse-1.0-cp312-cp312-manylinux_2_17_x86_64.manylinux2014_x86_64.whl"},
+		{name: "pypi full tag", client: pypi, pkg: "wheelhouse", opts: DownloadOptions{Wheel: "cp311-cp311-win_amd64"},
+			want: "wheelhouse-1.0-cp311-cp311-win_amd64.whl"},
+		{name: "pypi ambiguous tag", client: pypi, pkg: "wheelhouse", opts: DownloadOptions{Wheel: "win_amd64"},
+			wantErr: "2 wheels of version '1.0' of package 'wheelhouse' match 'win_amd64'"},
+		{name: "pypi missing tag", client: pypi, pkg: "wheelhouse", opts: DownloadOptions{Wheel: "macosx_11_0_arm64"},
+			wantErr: "no wheel of version '1.0' of package 'wheelhouse' for 'macosx_11_0_arm64'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "vendor")
+			tt.opts.Dir = dir
+
+			output, err := tt.client.Download(context.Background(), tt.pkg, tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Download() error = %v, want %q", err, tt.wantErr)
+				}
+				if entries, _ := os.ReadDir(dir); len(entries) > 0 {
+					t.Errorf("Download() wrote %v despite failing", entries)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Download() error = %v", err)
+			}
+
+			if output.File != filepath.Join(dir, tt.want) {
+				t.Errorf("File = %s, want %s", output.File, filepath.Join(dir, tt.want))
+			}
+			// Integrities of unsupported algorithms cannot be compared.
+			if output.Verified == tt.unverified || ((output.Verified || output.Weak) && output.Computed != output.Integrity) {
+				t.Errorf("Computed = %s, Integrity = %s, Verified = %v", output.Computed, output.Integrity, output.Verified)
+			}
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 1 || entries[0].Name() != tt.want {
+				t.Errorf("directory holds %v, want only %s", entries, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIntegrity(t *testing.T) {
+	sum := sha256.Sum256([]byte("artifact"))
+	tests := []struct {
+		integrity string
+		wantHex   bool
+		wantErr   bool
+	}{
+		{integrity: "sha256-" + hex.EncodeToString(sum[:]), wantHex: true},
+		{integrity: "sha256-" + base64.StdEncoding.EncodeToString(sum[:])},
+		{integrity: "sha512-" + base64.StdEncoding.EncodeToString(sum[:]), wantErr: true},
+		{integrity: "blake2b-" + hex.EncodeToString(sum[:]), wantErr: true},
+		{integrity: "sha256", wantErr: true},
+	}
+	for _, tt := range tests {
+		algorithm, digest, hexDigest, err := parseIntegrity(tt.integrity)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseIntegrity(%q) expected error", tt.integrity)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseIntegrity(%q) error = %v", tt.integrity, err)
+			continue
+		}
+		if hexDigest != tt.wantHex || string(digest) != string(sum[:]) {
+			t.Errorf("parseIntegrity(%q) = %x, hex %v", tt.integrity, digest, hexDigest)
+		}
+		if got := formatIntegrity(algorithm, digest, hexDigest); got != tt.integrity {
+			t.Errorf("formatIntegrity() = %s, want %s", got, tt.integrity)
+		}
+	}
+}
+
+func TestArtifactFile(t *testing.T) {
+	tests := []struct {
+		name, version, location, want string
+	}{
+		{"@types/node", "20.0.0", "https://registry.npmjs.org/@types/node/-/node-20.0.0.tgz", "node-20.0.0.tgz"},
+		{"serde", "1.0.0", "https://static.crates.io/crates/serde/serde-1.0.0.crate", "serde-1.0.0.crate"},
+		{"github.com/spf13/cobra", "v1.8.0", "https://proxy.golang.org/github.com/spf13/cobra/@v/v1.8.0.zip", "cobra-v1.8.0.zip"},
+	}
+	for _, tt := range tests {
+		got, err := artifactFile(tt.name, tt.version, tt.location)
+		if err != nil || got != tt.want {
+			t.Errorf("artifactFile(%q) = %q, %v, want %q", tt.location, got, err, tt.want)
+		}
+	}
+}
+
+func TestArtifactJuliaTreeHash(t *testing.T) {
+	c := &Client{ecosystem: "julia"}
+	v := registries.Version{
+		Number:    "1.0.0",
+		Integrity: "sha1-0123456789abcdef0123456789abcdef01234567",
+		Metadata:  map[string]any{"tarball": "https://pkg.julialang.org/package/uuid/0123456789abcdef0123456789abcdef01234567"},
+	}
+	art, err := c.artifact("Example", v)
+	if err != nil {
+		t.Fatalf("artifact() error = %v", err)
+	}
+	if art.integrity != "" {
+		t.Errorf("integrity = %q, want none for a git tree hash", art.integrity)
+	}
+}
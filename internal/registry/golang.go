@@ -0,0 +1,156 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/git-pkgs/registries/client"
+	"github.com/git-pkgs/vers"
+)
+
+// goProxyURL is the module proxy the golang ecosystem is queried through
+// unless GOPROXY names another.
+const goProxyURL = "https://proxy.golang.org"
+
+// goProxy returns the first module proxy GOPROXY lists, as the go command
+// would try first, or "" when it lists none, e.g. only "direct".
+func goProxy() string {
+	for _, entry := range strings.FieldsFunc(os.Getenv("GOPROXY"), func(r rune) bool { return r == ',' || r == '|' }) {
+		entry = strings.TrimSpace(entry)
+		if entry != "" && entry != "direct" && entry != "off" {
+			return strings.TrimSuffix(entry, "/")
+		}
+	}
+	return ""
+}
+
+// goModNotes are the parts of a go.mod file that describe the module rather
+// than build it.
+type goModNotes struct {
+	// Deprecated is the text of the "Deprecated:" comment on the module
+	// directive.
+	Deprecated  string
+	Retractions []goRetraction
+}
+
+// goRetraction is a retracted version, or an inclusive range of versions
+// when High differs from Low.
+type goRetraction struct {
+	Low, High string
+	Rationale string
+}
+
+// rationale returns the rationale of the retraction covering version.
+func (n goModNotes) rationale(version string) string {
+	for _, r := range n.Retractions {
+		if vers.CompareWithScheme(version, r.Low, "golang") >= 0 && vers.CompareWithScheme(version, r.High, "golang") <= 0 {
+			return r.Rationale
+		}
+	}
+	return ""
+}
+
+func (c *Client) fetchGoMod(ctx context.Context, module, version string) (string, error) {
+	proxy := goProxyURL
+	if c.baseURL != "" {
+		proxy = c.baseURL
+	}
+	url := fmt.Sprintf("%s/%s/@v/%s.mod", proxy, escapeGoPath(module), escapeGoPath(version))
+	body, err := client.DefaultClient().GetText(ctx, url)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch go.mod: %w", err)
+	}
+	return body, nil
+}
+
+// escapeGoPath escapes capital letters the way the module proxy protocol
+// requires, e.g. "github.com/Azure" becomes "github.com/!azure".
+func escapeGoPath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			b.WriteRune('!')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// parseGoModNotes reads the module deprecation and the retract directives
+// of a go.mod file. As with the go command, the rationale of a retraction
+// is the comment above or after it, or that of its enclosing block.
+func parseGoModNotes(gomod string) goModNotes {
+	var (
+		notes        goModNotes
+		leading      []string
+		inRetract    bool
+		blockComment string
+	)
+	for line := range strings.Lines(gomod) {
+		line = strings.TrimSpace(line)
+		code, comment, _ := strings.Cut(line, "//")
+		code = strings.TrimSpace(code)
+		comment = strings.TrimSpace(comment)
+
+		if code == "" {
+			if comment == "" {
+				leading = nil
+			} else {
+				leading = append(leading, comment)
+			}
+			continue
+		}
+		comments := leading
+		if comment != "" {
+			comments = append(comments, comment)
+		}
+		leading = nil
+		text := strings.Join(comments, " ")
+
+		fields := strings.Fields(code)
+		switch {
+		case inRetract && code == ")":
+			inRetract = false
+		case inRetract:
+			notes.addRetraction(code, text, blockComment)
+		case fields[0] == "module":
+			// The deprecation runs from "Deprecated:" to the end of the
+			// comment.
+			for i, c := range comments {
+				if deprecated, ok := strings.CutPrefix(c, "Deprecated:"); ok {
+					rest := append([]string{strings.TrimSpace(deprecated)}, comments[i+1:]...)
+					notes.Deprecated = strings.Join(rest, " ")
+					break
+				}
+			}
+		case fields[0] == "retract" && len(fields) > 1 && fields[1] == "(":
+			inRetract = true
+			blockComment = text
+		case fields[0] == "retract":
+			notes.addRetraction(strings.TrimSpace(strings.TrimPrefix(code, "retract")), text, "")
+		}
+	}
+	return notes
+}
+
+// addRetraction records a retracted version "v1.0.0" or range
+// "[v1.0.0, v1.1.0]".
+func (n *goModNotes) addRetraction(spec, rationale, fallback string) {
+	if rationale == "" {
+		rationale = fallback
+	}
+	low, high := spec, spec
+	if inner, ok := strings.CutPrefix(spec, "["); ok {
+		inner = strings.TrimSuffix(inner, "]")
+		l, h, ok := strings.Cut(inner, ",")
+		if !ok {
+			return
+		}
+		low, high = strings.TrimSpace(l), strings.TrimSpace(h)
+	}
+	n.Retractions = append(n.Retractions, goRetraction{Low: low, High: high, Rationale: rationale})
+}
@@ -0,0 +1,88 @@
+package registry
+
+import "testing"
+
+const testGoMod = `// Deprecated: use example.com/mod/v2
+// instead.
+module example.com/mod
+
+go 1.21
+
+require example.com/dep v1.0.0
+
+// Published too early.
+retract v1.0.0
+
+retract [v1.1.0, v1.1.5] // Data race in the cache.
+
+retract (
+	// Broken build.
+	v1.2.0
+	v1.2.1 // Wrong module path.
+)
+
+// Accidental tags.
+retract (
+	v0.9.0
+)
+`
+
+func TestParseGoModNotes(t *testing.T) {
+	notes := parseGoModNotes(testGoMod)
+
+	if want := "use example.com/mod/v2 instead."; notes.Deprecated != want {
+		t.Errorf("Deprecated = %q, want %q", notes.Deprecated, want)
+	}
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"v1.0.0", "Published too early."},
+		{"v1.1.0", "Data race in the cache."},
+		{"v1.1.3", "Data race in the cache."},
+		{"v1.1.6", ""},
+		{"v1.2.0", "Broken build."},
+		{"v1.2.1", "Wrong module path."},
+		{"v0.9.0", "Accidental tags."},
+		{"v1.3.0", ""},
+	}
+	for _, tt := range tests {
+		if got := notes.rationale(tt.version); got != tt.want {
+			t.Errorf("rationale(%s) = %q, want %q", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParseGoModNotesWithoutDeprecation(t *testing.T) {
+	notes := parseGoModNotes("// A module.\nmodule example.com/mod // comment\n")
+	if notes.Deprecated != "" || len(notes.Retractions) != 0 {
+		t.Errorf("unexpected notes: %+v", notes)
+	}
+}
+
+func TestEscapeGoPath(t *testing.T) {
+	if got, want := escapeGoPath("github.com/Azure/azure-sdk-for-go"), "github.com/!azure/azure-sdk-for-go"; got != want {
+		t.Errorf("escapeGoPath() = %q, want %q", got, want)
+	}
+}
+
+func TestGoProxy(t *testing.T) {
+	tests := []struct {
+		goproxy string
+		want    string
+	}{
+		{"", ""},
+		{"https://proxy.golang.org,direct", "https://proxy.golang.org"},
+		{"direct", ""},
+		{"off", ""},
+		{"direct,https://goproxy.example.com/", "https://goproxy.example.com"},
+		{"https://athens.internal|https://proxy.golang.org", "https://athens.internal"},
+	}
+	for _, tt := range tests {
+		t.Setenv("GOPROXY", tt.goproxy)
+		if got := goProxy(); got != tt.want {
+			t.Errorf("goProxy() with GOPROXY=%q = %q, want %q", tt.goproxy, got, tt.want)
+		}
+	}
+}
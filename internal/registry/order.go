@@ -0,0 +1,159 @@
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/git-pkgs/registries"
+	"github.com/git-pkgs/vers"
+)
+
+// Strategy decides which version Latest reports among those that pass the
+// filters. It implements [pflag.Value].
+type Strategy string
+
+const (
+	// StrategyHighest picks the highest version in the ecosystem's own
+	// ordering, preferring stable releases over prereleases.
+	StrategyHighest Strategy = "highest"
+	// StrategyNewest picks the most recently published version.
+	StrategyNewest Strategy = "newest"
+	// StrategyRegistry picks the version the registry reports as latest,
+	// e.g. npm's latest dist-tag, falling back to the highest version when
+	// the registry has none or it was filtered out.
+	StrategyRegistry Strategy = "registry"
+)
+
+func (s *Strategy) Set(value string) error {
+	switch Strategy(value) {
+	case StrategyHighest, StrategyNewest, StrategyRegistry:
+		*s = Strategy(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid strategy '%s', expected highest, newest or registry", value)
+	}
+}
+
+func (s *Strategy) String() string { return string(*s) }
+
+func (s *Strategy) Type() string { return "strategy" }
+
+// SortOrder is the order Versions lists versions in, newest or highest
+// first. It implements [pflag.Value].
+type SortOrder string
+
+const (
+	// SortDate orders versions by publish time, breaking ties and placing
+	// versions without one by version.
+	SortDate SortOrder = "date"
+	// SortVersion orders versions by the ecosystem's own version ordering.
+	SortVersion SortOrder = "version"
+)
+
+func (o *SortOrder) Set(value string) error {
+	switch SortOrder(value) {
+	case SortDate, SortVersion:
+		*o = SortOrder(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid sort order '%s', expected version or date", value)
+	}
+}
+
+func (o *SortOrder) String() string { return string(*o) }
+
+func (o *SortOrder) Type() string { return "order" }
+
+// compare orders two versions following the rules of the client's
+// ecosystem, e.g. PEP 440 for PyPI or Maven's ComparableVersion.
+func (c *Client) compare(a, b string) int {
+	return vers.CompareWithScheme(a, b, c.ecosystem)
+}
+
+// sortVersions orders versions newest or highest first, in place.
+func (c *Client) sortVersions(versions []registries.Version, order SortOrder) {
+	slices.SortStableFunc(versions, func(a, b registries.Version) int {
+		if order != SortVersion {
+			switch {
+			case a.PublishedAt.IsZero() && !b.PublishedAt.IsZero():
+				return 1
+			case !a.PublishedAt.IsZero() && b.PublishedAt.IsZero():
+				return -1
+			}
+			if cmp := b.PublishedAt.Compare(a.PublishedAt); cmp != 0 {
+				return cmp
+			}
+		}
+		return c.compare(b.Number, a.Number)
+	})
+}
+
+// selectVersion returns the version strategy picks among the versions in
+// good standing, or nil when there is none. registryLatest is the version
+// the registry reports as latest, only used by StrategyRegistry.
+func (c *Client) selectVersion(versions []registries.Version, strategy Strategy, registryLatest string) *registries.Version {
+	switch strategy {
+	case StrategyNewest:
+		return selectLatest(versions)
+	case StrategyRegistry:
+		for i, v := range versions {
+			if v.Number == registryLatest && v.Status == registries.StatusNone {
+				return &versions[i]
+			}
+		}
+	}
+	return c.selectHighest(versions)
+}
+
+// selectHighest returns the highest stable version in good standing, or the
+// highest prerelease when there is no stable one.
+func (c *Client) selectHighest(versions []registries.Version) *registries.Version {
+	var highest, highestPre *registries.Version
+	for i, v := range versions {
+		if v.Status != registries.StatusNone {
+			continue
+		}
+		best := &highest
+		if c.isPrerelease(v.Number) {
+			best = &highestPre
+		}
+		if *best == nil || c.compare(v.Number, (*best).Number) > 0 {
+			*best = &versions[i]
+		}
+	}
+	if highest != nil {
+		return highest
+	}
+	return highestPre
+}
+
+var (
+	// pep440Pre matches PEP 440 pre- and development releases, e.g. 1.0a1,
+	// 2.0rc2 and 3.0.dev4, but not post-releases.
+	pep440Pre = regexp.MustCompile(`(?i)\d[-_.]?(a|alpha|b|beta|c|rc|pre|preview|dev)[-_.]?\d*($|[-_.+])`)
+	// mavenPre matches the Maven qualifiers ComparableVersion orders before
+	// the release, e.g. 1.0-alpha-1, 2.0-M3 and 3.0-SNAPSHOT.
+	mavenPre = regexp.MustCompile(`(?i)[-.](alpha|beta|milestone|rc|cr|snapshot|[abm]\d+)`)
+	// gemPre matches RubyGems prereleases, which are any version with a
+	// letter in it. The platform suffix must be cut off first.
+	gemPre = regexp.MustCompile(`[a-zA-Z]`)
+)
+
+// isPrerelease tells whether version is a prerelease in the client's
+// ecosystem. Other ecosystems follow SemVer, where it has a "-" suffix.
+func (c *Client) isPrerelease(version string) bool {
+	switch c.ecosystem {
+	case "pypi":
+		local, _, _ := strings.Cut(version, "+")
+		return pep440Pre.MatchString(local)
+	case "maven", "clojars":
+		return mavenPre.MatchString(version)
+	case "gem":
+		number, _, _ := strings.Cut(version, "-")
+		return gemPre.MatchString(number)
+	}
+	info, err := vers.ParseVersion(strings.TrimPrefix(version, "v"))
+	return err == nil && info.IsPrerelease()
+}
@@ -0,0 +1,154 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/git-pkgs/registries"
+)
+
+func TestSelectVersion(t *testing.T) {
+	day := 24 * time.Hour
+	now := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		ecosystem      string
+		versions       []registries.Version
+		strategy       Strategy
+		registryLatest string
+		want           string
+	}{
+		{
+			name:      "highest ignores a backport published later",
+			ecosystem: "npm",
+			versions:  []registries.Version{v("4.0.0", now.Add(-10*day), ""), v("3.9.5", now.Add(-1*day), "")},
+			want:      "4.0.0",
+		},
+		{
+			name:      "newest picks the backport",
+			ecosystem: "npm",
+			versions:  []registries.Version{v("4.0.0", now.Add(-10*day), ""), v("3.9.5", now.Add(-1*day), "")},
+			strategy:  StrategyNewest,
+			want:      "3.9.5",
+		},
+		{
+			name:      "highest prefers stable releases",
+			ecosystem: "npm",
+			versions:  []registries.Version{v("4.0.0", time.Time{}, ""), v("5.0.0-beta.1", time.Time{}, "")},
+			want:      "4.0.0",
+		},
+		{
+			name:      "highest falls back to prereleases",
+			ecosystem: "npm",
+			versions:  []registries.Version{v("5.0.0-beta.1", time.Time{}, ""), v("5.0.0-beta.2", time.Time{}, "")},
+			want:      "5.0.0-beta.2",
+		},
+		{
+			name:      "highest skips yanked versions",
+			ecosystem: "cargo",
+			versions:  []registries.Version{v("1.10.0", time.Time{}, registries.StatusYanked), v("1.9.0", time.Time{}, "")},
+			want:      "1.9.0",
+		},
+		{
+			name:      "PEP 440 ordering",
+			ecosystem: "pypi",
+			versions:  []registries.Version{v("1.10", time.Time{}, ""), v("1.9.post1", time.Time{}, ""), v("1.11rc1", time.Time{}, ""), v("1.11.dev0", time.Time{}, "")},
+			want:      "1.10",
+		},
+		{
+			name:      "Maven ordering",
+			ecosystem: "maven",
+			versions:  []registries.Version{v("1.0", time.Time{}, ""), v("1.0-SNAPSHOT", time.Time{}, ""), v("1.1-alpha-1", time.Time{}, ""), v("1.0.1", time.Time{}, "")},
+			want:      "1.0.1",
+		},
+		{
+			name:      "RubyGems ordering with platforms",
+			ecosystem: "gem",
+			versions:  []registries.Version{v("1.2.0-x86_64-linux", time.Time{}, ""), v("1.10.0", time.Time{}, ""), v("2.0.0.rc1", time.Time{}, "")},
+			want:      "1.10.0",
+		},
+		{
+			name:      "Go modules with +incompatible",
+			ecosystem: "golang",
+			versions:  []registries.Version{v("v2.0.0+incompatible", time.Time{}, ""), v("v1.9.0", time.Time{}, ""), v("v2.1.0-0.20240101000000-abcdef123456", time.Time{}, "")},
+			want:      "v2.0.0+incompatible",
+		},
+		{
+			name:           "registry picks the reported latest",
+			ecosystem:      "npm",
+			versions:       []registries.Version{v("4.0.0", time.Time{}, ""), v("5.0.0", time.Time{}, "")},
+			strategy:       StrategyRegistry,
+			registryLatest: "4.0.0",
+			want:           "4.0.0",
+		},
+		{
+			name:           "registry falls back to highest when filtered out",
+			ecosystem:      "npm",
+			versions:       []registries.Version{v("4.0.0", time.Time{}, ""), v("3.0.0", time.Time{}, "")},
+			strategy:       StrategyRegistry,
+			registryLatest: "5.0.0",
+			want:           "4.0.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{ecosystem: tt.ecosystem}
+			got := c.selectVersion(tt.versions, tt.strategy, tt.registryLatest)
+			if got == nil {
+				t.Fatalf("got nil, want %s", tt.want)
+			}
+			if got.Number != tt.want {
+				t.Errorf("got %s, want %s", got.Number, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortVersions(t *testing.T) {
+	day := 24 * time.Hour
+	now := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	versions := func() []registries.Version {
+		return []registries.Version{
+			v("1.9.0", now.Add(-10*day), ""),
+			v("1.10.0", now.Add(-5*day), ""),
+			v("1.9.1", now.Add(-1*day), ""),
+			v("0.1.0", time.Time{}, ""),
+			v("0.2.0", time.Time{}, ""),
+		}
+	}
+
+	tests := []struct {
+		order SortOrder
+		want  []string
+	}{
+		{SortDate, []string{"1.9.1", "1.10.0", "1.9.0", "0.2.0", "0.1.0"}},
+		{"", []string{"1.9.1", "1.10.0", "1.9.0", "0.2.0", "0.1.0"}},
+		{SortVersion, []string{"1.10.0", "1.9.1", "1.9.0", "0.2.0", "0.1.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			got := versions()
+			(&Client{ecosystem: "npm"}).sortVersions(got, tt.order)
+			for i, ver := range got {
+				if ver.Number != tt.want[i] {
+					t.Fatalf("got %v, want %v", numbers(got), tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestStrategySet(t *testing.T) {
+	var s Strategy
+	if err := s.Set("newest"); err != nil || s != StrategyNewest {
+		t.Errorf("Set(newest) = %v, strategy %q", err, s)
+	}
+	if err := s.Set("oldest"); err == nil {
+		t.Error("expected error for an unknown strategy")
+	}
+	var o SortOrder
+	if err := o.Set("semver"); err == nil {
+		t.Error("expected error for an unknown sort order")
+	}
+}
@@ -0,0 +1,161 @@
+package registry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/manifest"
+	"github.com/acidghost/a555pq/internal/timespan"
+	"github.com/git-pkgs/registries"
+)
+
+// DefaultOutdatedConcurrency is the number of registry requests Outdated
+// runs at once unless told otherwise.
+const DefaultOutdatedConcurrency = 16
+
+// OutdatedOptions controls how Outdated looks dependencies up.
+type OutdatedOptions struct {
+	// MinReleaseAge applies to the wanted and latest versions. The current
+	// version is reported whatever its age.
+	Options
+	// Concurrency bounds the registry requests running at once. Zero means
+	// DefaultOutdatedConcurrency.
+	Concurrency int
+}
+
+// Outdated reports, for each dependency, its current version, the highest
+// version its requirement allows and the highest version overall, along
+// with their ages. Each package is fetched once, concurrently, from the
+// registry of its ecosystem. Lookup failures are reported per dependency.
+// Dependencies locked from elsewhere than the registry are not looked up.
+func Outdated(ctx context.Context, deps []manifest.Dependency, opts OutdatedOptions) []formatter.OutdatedItem {
+	return newOutdatedChecker(opts, New).run(ctx, deps, time.Now())
+}
+
+type outdatedChecker struct {
+	opts OutdatedOptions
+	*versionFetcher
+}
+
+func newOutdatedChecker(opts OutdatedOptions, newClient func(string) (*Client, error)) *outdatedChecker {
+	return &outdatedChecker{opts: opts, versionFetcher: newVersionFetcher(newClient, opts.Concurrency, DefaultOutdatedConcurrency)}
+}
+
+// versionFetcher fetches the versions of packages across ecosystems, each
+// package once however many ask for it, with one client per ecosystem.
+type versionFetcher struct {
+	newClient func(ecosystem string) (*Client, error)
+	// sem bounds the registry requests in flight.
+	sem      chan struct{}
+	clients  memo[*Client]
+	versions memo[[]registries.Version]
+}
+
+func newVersionFetcher(newClient func(string) (*Client, error), concurrency, defaultConcurrency int) *versionFetcher {
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	return &versionFetcher{newClient: newClient, sem: make(chan struct{}, concurrency)}
+}
+
+func (f *versionFetcher) fetch(ctx context.Context, ecosystem, name string) (*Client, []registries.Version, error) {
+	c, err := f.clients.get(ecosystem, func() (*Client, error) {
+		return f.newClient(ecosystem)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	versions, err := f.versions.get(ecosystem+" "+name, func() ([]registries.Version, error) {
+		f.sem <- struct{}{}
+		defer func() { <-f.sem }()
+		return c.reg.FetchVersions(ctx, name)
+	})
+	if err != nil {
+		return nil, nil, c.mapError(name, err)
+	}
+	return c, versions, nil
+}
+
+func (o *outdatedChecker) run(ctx context.Context, deps []manifest.Dependency, now time.Time) []formatter.OutdatedItem {
+	items := make([]formatter.OutdatedItem, len(deps))
+	var wg sync.WaitGroup
+	for i, dep := range deps {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items[i] = o.check(ctx, dep, now)
+		}()
+	}
+	wg.Wait()
+	return items
+}
+
+func (o *outdatedChecker) check(ctx context.Context, dep manifest.Dependency, now time.Time) formatter.OutdatedItem {
+	item := formatter.OutdatedItem{
+		Ecosystem:   dep.Ecosystem,
+		Name:        dep.Name,
+		Requirement: dep.Requirements,
+		Current:     dep.Version,
+	}
+	if dep.Scope != manifest.Runtime {
+		item.Scope = dep.Scope
+	}
+	if dep.Source != "" {
+		item.Source = dep.Source
+		return item
+	}
+
+	c, versions, err := o.fetch(ctx, dep.Ecosystem, dep.Name)
+	if err != nil {
+		item.Error = err.Error()
+		return item
+	}
+
+	for _, v := range versions {
+		if v.Number == dep.Version {
+			item.CurrentAge = versionAge(v, now)
+			break
+		}
+	}
+
+	versions = filterByMinReleaseAgeAt(versions, o.opts.MinReleaseAge, now)
+	if v := c.selectHighest(versions); v != nil {
+		item.Latest, item.LatestAge = v.Number, versionAge(*v, now)
+	}
+	if v := c.wanted(versions, dep); v != nil {
+		item.Wanted, item.WantedAge = v.Number, versionAge(*v, now)
+	}
+	return item
+}
+
+// wanted selects the highest version a dependency's requirement allows. A
+// go.mod requirement is the minimum version, as minimal version selection
+// goes. Requirements the ecosystem's range syntax does not cover only match
+// a version of that exact name.
+func (c *Client) wanted(versions []registries.Version, dep manifest.Dependency) *registries.Version {
+	requirement := dep.Requirements
+	if c.ecosystem == "golang" && requirement != "" {
+		requirement = ">=" + requirement
+	}
+	rng, err := c.parseRange(requirement)
+	if err != nil {
+		for i, v := range versions {
+			if v.Number == dep.Requirements {
+				return &versions[i]
+			}
+		}
+		return nil
+	}
+	return c.selectHighest(filterByRange(versions, rng))
+}
+
+// versionAge is how long ago a version was published, empty when the
+// registry does not tell.
+func versionAge(v registries.Version, now time.Time) string {
+	if v.PublishedAt.IsZero() {
+		return ""
+	}
+	return timespan.Format(now.Sub(v.PublishedAt))
+}
@@ -0,0 +1,84 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/acidghost/a555pq/internal/manifest"
+	"github.com/git-pkgs/registries"
+	"github.com/git-pkgs/registries/client"
+)
+
+// datedRegistry serves versions published the given number of days before
+// outdatedNow.
+type datedRegistry struct {
+	registries.Registry
+	versions map[string]map[string]int
+}
+
+var outdatedNow = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func (r datedRegistry) FetchVersions(_ context.Context, name string) ([]registries.Version, error) {
+	days, ok := r.versions[name]
+	if !ok {
+		return nil, client.ErrNotFound
+	}
+	var versions []registries.Version
+	for number, d := range days {
+		versions = append(versions, registries.Version{Number: number, PublishedAt: outdatedNow.AddDate(0, 0, -d)})
+	}
+	return versions, nil
+}
+
+func TestOutdated(t *testing.T) {
+	reg := datedRegistry{versions: map[string]map[string]int{
+		"react":            {"17.0.2": 1400, "18.2.0": 800, "18.3.1": 200, "19.0.0": 10},
+		"github.com/pkg/x": {"v1.8.0": 400, "v1.9.0": 100},
+	}}
+	newClient := func(ecosystem string) (*Client, error) {
+		if ecosystem == "cargo" {
+			return nil, fmt.Errorf("unsupported ecosystem: %s", ecosystem)
+		}
+		return &Client{reg: reg, ecosystem: ecosystem}, nil
+	}
+	deps := []manifest.Dependency{
+		{Ecosystem: "npm", Name: "react", Requirements: "^18.2.0", Version: "18.2.0", Scope: manifest.Runtime},
+		{Ecosystem: "npm", Name: "react", Requirements: "^17.0.0", Scope: manifest.Development},
+		{Ecosystem: "golang", Name: "github.com/pkg/x", Requirements: "v1.8.0", Version: "v1.8.0", Scope: manifest.Runtime},
+		{Ecosystem: "npm", Name: "missing", Requirements: "*", Scope: manifest.Runtime},
+		{Ecosystem: "cargo", Name: "serde", Scope: manifest.Runtime},
+		{Ecosystem: "npm", Name: "forked", Version: "1.0.0", Scope: manifest.Runtime, Source: "git+https://github.com/org/forked.git#0123abcd"},
+	}
+	opts := OutdatedOptions{Options: Options{MinReleaseAge: 30 * 24 * time.Hour}}
+
+	got := newOutdatedChecker(opts, newClient).run(context.Background(), deps, outdatedNow)
+	want := []formatter.OutdatedItem{
+		{
+			Ecosystem: "npm", Name: "react", Requirement: "^18.2.0",
+			Current: "18.2.0", CurrentAge: "2y",
+			Wanted: "18.3.1", WantedAge: "6mo",
+			Latest: "18.3.1", LatestAge: "6mo",
+		},
+		{
+			Ecosystem: "npm", Name: "react", Scope: manifest.Development, Requirement: "^17.0.0",
+			Wanted: "17.0.2", WantedAge: "3y",
+			Latest: "18.3.1", LatestAge: "6mo",
+		},
+		{
+			Ecosystem: "golang", Name: "github.com/pkg/x", Requirement: "v1.8.0",
+			Current: "v1.8.0", CurrentAge: "1y",
+			Wanted: "v1.9.0", WantedAge: "3mo",
+			Latest: "v1.9.0", LatestAge: "3mo",
+		},
+		{Ecosystem: "npm", Name: "missing", Requirement: "*", Error: "package 'missing' not found"},
+		{Ecosystem: "cargo", Name: "serde", Error: "unsupported ecosystem: cargo"},
+		{Ecosystem: "npm", Name: "forked", Current: "1.0.0", Source: "git+https://github.com/org/forked.git#0123abcd"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Outdated() =\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -0,0 +1,282 @@
+package registry
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/git-pkgs/registries"
+	"github.com/git-pkgs/registries/client"
+)
+
+// npmRegistryURL is the npm registry signing keys are fetched from unless
+// the client queries another.
+const npmRegistryURL = "https://registry.npmjs.org"
+
+// Registry signature statuses.
+const (
+	SignatureValid      = "valid"
+	SignatureInvalid    = "invalid"
+	SignatureExpiredKey = "expired key"
+	SignatureUnknownKey = "unknown key"
+)
+
+// npmKeyScheme is the only signing scheme the npm registry uses.
+const npmKeyScheme = "ecdsa-sha2-nistp256"
+
+// npmKey is a registry signing key, as listed at /-/npm/v1/keys.
+type npmKey struct {
+	// Expires is when the key stopped signing, nil while it is in use.
+	Expires *time.Time `json:"expires"`
+	KeyID   string     `json:"keyid"`
+	KeyType string     `json:"keytype"`
+	Scheme  string     `json:"scheme"`
+	// Key is the base64 DER encoded public key.
+	Key string `json:"key"`
+}
+
+func (c *Client) npmURL() string {
+	if c.baseURL != "" {
+		return c.baseURL
+	}
+	return npmRegistryURL
+}
+
+func (c *Client) npmKeys(ctx context.Context) ([]npmKey, error) {
+	var resp struct {
+		Keys []npmKey `json:"keys"`
+	}
+	if err := client.DefaultClient().GetJSON(ctx, c.npmURL()+"/-/npm/v1/keys", &resp); err != nil {
+		return nil, fmt.Errorf("failed to fetch registry signing keys: %w", err)
+	}
+	return resp.Keys, nil
+}
+
+// Provenance reports how an npm version was published: through trusted
+// publishing or not, the build provenance attested in its Sigstore bundle,
+// and whether its registry signatures verify. The version is opts.Version,
+// or the registry's latest. The Sigstore bundle is decoded, not verified.
+func (c *Client) Provenance(ctx context.Context, name string, opts Options) (*formatter.ProvenanceOutput, error) {
+	if c.ecosystem != "npm" {
+		return nil, fmt.Errorf("provenance is only published by npm")
+	}
+
+	pkg, err := c.reg.FetchPackage(ctx, name)
+	if err != nil {
+		return nil, c.mapError(name, err)
+	}
+	versions, err := c.reg.FetchVersions(ctx, name)
+	if err != nil {
+		return nil, c.mapError(name, err)
+	}
+	number, err := c.showVersion(pkg, versions, true, opts)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(versions, func(v registries.Version) bool { return v.Number == number })
+	if i < 0 {
+		return nil, fmt.Errorf("version '%s' of package '%s' not found", number, name)
+	}
+	version := versions[i]
+
+	keys, err := c.npmKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &formatter.ProvenanceOutput{
+		Package:          name,
+		Version:          version.Number,
+		TrustedPublisher: trustedPublisher(version),
+		Signatures:       verifySignatures(name, version, keys),
+	}
+	if att, _ := registries.NPMProvenance(&version); att != nil {
+		output.Provenance = fetchProvenance(ctx, att)
+	}
+	return output, nil
+}
+
+// trustedPublisher names the CI provider that published a version through
+// trusted publishing, e.g. "github", as npm records in _npmUser.
+func trustedPublisher(v registries.Version) string {
+	user, _ := v.Metadata["_npmUser"].(map[string]any)
+	publisher, _ := user["trustedPublisher"].(map[string]any)
+	id, _ := publisher["id"].(string)
+	return id
+}
+
+// verifySignatures checks each registry signature of a version, an ECDSA
+// P-256 signature over "name@version:integrity" by the key it names. A key
+// that expired before the version was published does not vouch for it.
+func verifySignatures(name string, v registries.Version, keys []npmKey) []formatter.SignatureItem {
+	_, sigs := registries.NPMProvenance(&v)
+	digest := sha256.Sum256([]byte(name + "@" + v.Number + ":" + v.Integrity))
+
+	items := make([]formatter.SignatureItem, 0, len(sigs))
+	for _, sig := range sigs {
+		item := formatter.SignatureItem{KeyID: sig.Keyid, Status: SignatureUnknownKey}
+		i := slices.IndexFunc(keys, func(k npmKey) bool { return k.KeyID == sig.Keyid })
+		if i >= 0 {
+			item.Status = verifySignature(keys[i], sig.Sig, digest[:], v.PublishedAt)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func verifySignature(key npmKey, sig string, digest []byte, published time.Time) string {
+	if key.Scheme != npmKeyScheme {
+		return SignatureUnknownKey
+	}
+	if key.Expires != nil && !published.IsZero() && published.After(*key.Expires) {
+		return SignatureExpiredKey
+	}
+	der, err := base64.StdEncoding.DecodeString(key.Key)
+	if err != nil {
+		return SignatureUnknownKey
+	}
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return SignatureUnknownKey
+	}
+	pub, ok := parsed.(*ecdsa.PublicKey)
+	if !ok {
+		return SignatureUnknownKey
+	}
+	raw, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil || !ecdsa.VerifyASN1(pub, digest, raw) {
+		return SignatureInvalid
+	}
+	return SignatureValid
+}
+
+// signatureSummary sums up the registry signatures of a version: invalid
+// when any fails to verify or is made with a key that expired before the
+// version was published, as npm has it, valid when one verifies, empty
+// without signatures.
+func signatureSummary(items []formatter.SignatureItem) string {
+	summary := ""
+	for _, item := range items {
+		switch item.Status {
+		case SignatureInvalid, SignatureExpiredKey:
+			return SignatureInvalid
+		case SignatureValid:
+			summary = SignatureValid
+		}
+	}
+	return summary
+}
+
+// provenanceLabel sums up how a version was published, from the strongest
+// evidence down: "invalid signature" when a registry signature fails to
+// verify or was made with an expired key, then "trusted publishing", "provenance", "signed" or "none".
+func provenanceLabel(name string, v registries.Version, keys []npmKey) string {
+	signatures := signatureSummary(verifySignatures(name, v, keys))
+	att, _ := registries.NPMProvenance(&v)
+	switch {
+	case signatures == SignatureInvalid:
+		return "invalid signature"
+	case trustedPublisher(v) != "":
+		return "trusted publishing"
+	case att != nil:
+		return "provenance"
+	case signatures == SignatureValid:
+		return "signed"
+	default:
+		return "none"
+	}
+}
+
+// npmAttestations is the response of the attestations endpoint a version's
+// dist.attestations points at.
+type npmAttestations struct {
+	Attestations []struct {
+		PredicateType string `json:"predicateType"`
+		Bundle        struct {
+			DSSEEnvelope struct {
+				Payload     string `json:"payload"`
+				PayloadType string `json:"payloadType"`
+			} `json:"dsseEnvelope"`
+		} `json:"bundle"`
+	} `json:"attestations"`
+}
+
+// slsaStatement is the part of an in-toto statement with a SLSA v1
+// provenance predicate that names the source and the build.
+type slsaStatement struct {
+	PredicateType string `json:"predicateType"`
+	Predicate     struct {
+		BuildDefinition struct {
+			ExternalParameters struct {
+				Workflow struct {
+					Ref        string `json:"ref"`
+					Repository string `json:"repository"`
+					Path       string `json:"path"`
+				} `json:"workflow"`
+			} `json:"externalParameters"`
+			ResolvedDependencies []struct {
+				URI    string            `json:"uri"`
+				Digest map[string]string `json:"digest"`
+			} `json:"resolvedDependencies"`
+		} `json:"buildDefinition"`
+		RunDetails struct {
+			Builder struct {
+				ID string `json:"id"`
+			} `json:"builder"`
+			Metadata struct {
+				InvocationID string `json:"invocationId"`
+			} `json:"metadata"`
+		} `json:"runDetails"`
+	} `json:"predicate"`
+}
+
+// fetchProvenance reads the build provenance out of the Sigstore bundle the
+// attestation points at. Failures are reported in the result, as the
+// registry signatures are still worth showing.
+func fetchProvenance(ctx context.Context, att *registries.NPMAttestationRef) *formatter.ProvenanceInfo {
+	info := &formatter.ProvenanceInfo{PredicateType: att.Provenance.PredicateType}
+
+	var resp npmAttestations
+	if err := client.DefaultClient().GetJSON(ctx, att.URL, &resp); err != nil {
+		info.Error = fmt.Sprintf("failed to fetch attestations: %v", err)
+		return info
+	}
+	for _, a := range resp.Attestations {
+		if a.PredicateType != att.Provenance.PredicateType {
+			continue
+		}
+		payload, err := base64.StdEncoding.DecodeString(a.Bundle.DSSEEnvelope.Payload)
+		if err != nil {
+			info.Error = fmt.Sprintf("failed to decode provenance: %v", err)
+			return info
+		}
+		var statement slsaStatement
+		if err := json.Unmarshal(payload, &statement); err != nil {
+			info.Error = fmt.Sprintf("failed to decode provenance: %v", err)
+			return info
+		}
+
+		build := statement.Predicate.BuildDefinition
+		info.Repository = build.ExternalParameters.Workflow.Repository
+		info.Workflow = build.ExternalParameters.Workflow.Path
+		info.Ref = build.ExternalParameters.Workflow.Ref
+		for _, dep := range build.ResolvedDependencies {
+			if commit := dep.Digest["gitCommit"]; commit != "" {
+				info.Commit = commit
+				break
+			}
+		}
+		info.Builder = statement.Predicate.RunDetails.Builder.ID
+		info.BuildURL = statement.Predicate.RunDetails.Metadata.InvocationID
+		return info
+	}
+	info.Error = fmt.Sprintf("no %s attestation found", att.Provenance.PredicateType)
+	return info
+}
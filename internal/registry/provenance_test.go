@@ -0,0 +1,232 @@
+package registry
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/git-pkgs/registries"
+)
+
+// npmSigner signs package versions the way the npm registry does.
+type npmSigner struct {
+	keyID string
+	key   *ecdsa.PrivateKey
+}
+
+func newNPMSigner(t *testing.T, keyID string) npmSigner {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return npmSigner{keyID: keyID, key: key}
+}
+
+func (s npmSigner) sign(t *testing.T, message string) map[string]string {
+	t.Helper()
+	digest := sha256.Sum256([]byte(message))
+	sig, err := ecdsa.SignASN1(rand.Reader, s.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]string{"keyid": s.keyID, "sig": base64.StdEncoding.EncodeToString(sig)}
+}
+
+func (s npmSigner) publicKey(t *testing.T, expires any) map[string]any {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(&s.key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]any{
+		"expires": expires,
+		"keyid":   s.keyID,
+		"keytype": "ecdsa-sha2-nistp256",
+		"scheme":  "ecdsa-sha2-nistp256",
+		"key":     base64.StdEncoding.EncodeToString(der),
+	}
+}
+
+// newNPMServer stands in for the npm registry, serving a package whose
+// versions show every kind of provenance.
+func newNPMServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	current := newNPMSigner(t, "SHA256:current")
+	retired := newNPMSigner(t, "SHA256:retired")
+	srv := httptest.NewServer(nil)
+	t.Cleanup(srv.Close)
+
+	statement, _ := json.Marshal(map[string]any{
+		"_type":         "https://in-toto.io/Statement/v1",
+		"predicateType": "https://slsa.dev/provenance/v1",
+		"predicate": map[string]any{
+			"buildDefinition": map[string]any{
+				"externalParameters": map[string]any{"workflow": map[string]any{
+					"ref":        "refs/tags/v1.3.0",
+					"repository": "https://github.com/left/pad",
+					"path":       ".github/workflows/release.yml",
+				}},
+				"resolvedDependencies": []any{map[string]any{
+					"uri":    "git+https://github.com/left/pad@refs/tags/v1.3.0",
+					"digest": map[string]string{"gitCommit": "0123abcd"},
+				}},
+			},
+			"runDetails": map[string]any{
+				"builder":  map[string]any{"id": "https://github.com/actions/runner/github-hosted"},
+				"metadata": map[string]any{"invocationId": "https://github.com/left/pad/actions/runs/1/attempts/1"},
+			},
+		},
+	})
+
+	version := func(number string, dist map[string]any, extra map[string]any) map[string]any {
+		v := map[string]any{"name": "left-pad", "version": number, "dist": dist}
+		for k, val := range extra {
+			v[k] = val
+		}
+		return v
+	}
+	packument := map[string]any{
+		"name":      "left-pad",
+		"dist-tags": map[string]string{"latest": "1.3.0"},
+		"time": map[string]string{
+			"1.0.0": "2020-01-01T00:00:00Z",
+			"1.1.0": "2021-01-01T00:00:00Z",
+			"1.2.0": "2022-01-01T00:00:00Z",
+			"1.3.0": "2024-01-01T00:00:00Z",
+		},
+		"versions": map[string]any{
+			"1.0.0": version("1.0.0", map[string]any{"integrity": "sha512-one"}, nil),
+			"1.1.0": version("1.1.0", map[string]any{
+				"integrity":  "sha512-two",
+				"signatures": []any{current.sign(t, "left-pad@1.1.0:sha512-tampered")},
+			}, nil),
+			"1.2.0": version("1.2.0", map[string]any{
+				"integrity":  "sha512-three",
+				"signatures": []any{retired.sign(t, "left-pad@1.2.0:sha512-three")},
+			}, nil),
+			"1.3.0": version("1.3.0", map[string]any{
+				"integrity": "sha512-four",
+				"attestations": map[string]any{
+					"url":        srv.URL + "/-/npm/v1/attestations/left-pad@1.3.0",
+					"provenance": map[string]string{"predicateType": "https://slsa.dev/provenance/v1"},
+				},
+				"signatures": []any{
+					current.sign(t, "left-pad@1.3.0:sha512-four"),
+					retired.sign(t, "left-pad@1.3.0:sha512-four"),
+					map[string]string{"keyid": "SHA256:unknown", "sig": "AAAA"},
+				},
+			}, map[string]any{"_npmUser": map[string]any{"name": "GitHub Actions", "trustedPublisher": map[string]string{"id": "github"}}}),
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/left-pad", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(packument)
+	})
+	mux.HandleFunc("/-/npm/v1/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []any{
+			current.publicKey(t, nil),
+			retired.publicKey(t, "2023-01-01T00:00:00.000Z"),
+		}})
+	})
+	mux.HandleFunc("/-/npm/v1/attestations/left-pad@1.3.0", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"attestations": []any{
+			map[string]any{"predicateType": "https://github.com/npm/attestation/tree/main/specs/publish/v0.1"},
+			map[string]any{
+				"predicateType": "https://slsa.dev/provenance/v1",
+				"bundle": map[string]any{"dsseEnvelope": map[string]any{
+					"payload":     base64.StdEncoding.EncodeToString(statement),
+					"payloadType": "application/vnd.in-toto+json",
+				}},
+			},
+		}})
+	})
+	srv.Config.Handler = mux
+	return srv
+}
+
+func newNPMTestClient(t *testing.T) *Client {
+	t.Helper()
+	srv := newNPMServer(t)
+	reg, err := registries.New("npm", srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Client{reg: reg, ecosystem: "npm", baseURL: srv.URL}
+}
+
+func TestProvenance(t *testing.T) {
+	c := newNPMTestClient(t)
+
+	output, err := c.Provenance(context.Background(), "left-pad", Options{})
+	if err != nil {
+		t.Fatalf("Provenance() error = %v", err)
+	}
+	want := &formatter.ProvenanceOutput{
+		Package:          "left-pad",
+		Version:          "1.3.0",
+		TrustedPublisher: "github",
+		Provenance: &formatter.ProvenanceInfo{
+			PredicateType: "https://slsa.dev/provenance/v1",
+			Repository:    "https://github.com/left/pad",
+			Workflow:      ".github/workflows/release.yml",
+			Ref:           "refs/tags/v1.3.0",
+			Commit:        "0123abcd",
+			Builder:       "https://github.com/actions/runner/github-hosted",
+			BuildURL:      "https://github.com/left/pad/actions/runs/1/attempts/1",
+		},
+		Signatures: []formatter.SignatureItem{
+			{KeyID: "SHA256:current", Status: SignatureValid},
+			{KeyID: "SHA256:retired", Status: SignatureExpiredKey},
+			{KeyID: "SHA256:unknown", Status: SignatureUnknownKey},
+		},
+	}
+	if !reflect.DeepEqual(output, want) {
+		t.Errorf("Provenance() =\n%+v\nwant\n%+v", output, want)
+	}
+
+	output, err = c.Provenance(context.Background(), "left-pad", Options{Version: "1.1.0"})
+	if err != nil {
+		t.Fatalf("Provenance() error = %v", err)
+	}
+	if output.Provenance != nil || output.TrustedPublisher != "" {
+		t.Errorf("expected no provenance for 1.1.0, got %+v", output)
+	}
+	if len(output.Signatures) != 1 || output.Signatures[0].Status != SignatureInvalid {
+		t.Errorf("Signatures = %+v, want one invalid signature", output.Signatures)
+	}
+}
+
+func TestVersionsProvenance(t *testing.T) {
+	c := newNPMTestClient(t)
+
+	output, err := c.Versions(context.Background(), "left-pad", Options{Provenance: true, Sort: SortVersion})
+	if err != nil {
+		t.Fatalf("Versions() error = %v", err)
+	}
+	got := make(map[string]string)
+	for _, v := range output.Versions {
+		got[v.Version] = v.Provenance
+	}
+	want := map[string]string{
+		"1.0.0": "none",
+		"1.1.0": "invalid signature",
+		"1.2.0": "signed",
+		// Signed by the retired key after it expired.
+		"1.3.0": "invalid signature",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Provenance = %v, want %v", got, want)
+	}
+}
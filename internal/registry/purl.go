@@ -0,0 +1,24 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/git-pkgs/registries"
+)
+
+// NewFromPURL creates a client for the ecosystem of a package URL such as
+// pkg:npm/%40babel/core@7.24.0, and returns the package name and version it
+// refers to. The version is empty when the package URL has none. A
+// repository_url qualifier points the client at a private registry.
+func NewFromPURL(purl string) (client *Client, name, version string, err error) {
+	p, err := registries.ParsePURL(purl)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("unsupported package URL '%s': %w", purl, err)
+	}
+	client, err = newClientAt(p.Type, p.RepositoryURL())
+	if err != nil {
+		return nil, "", "", fmt.Errorf("unsupported package URL '%s': %w", purl, err)
+	}
+	client.ecosystem = client.reg.Ecosystem()
+	return client, p.FullName(), p.Version, nil
+}
@@ -0,0 +1,66 @@
+package registry
+
+import "testing"
+
+func TestNewFromPURL(t *testing.T) {
+	t.Setenv("GOPROXY", "")
+	tests := []struct {
+		purl        string
+		ecosystem   string
+		wantName    string
+		wantVersion string
+		wantBaseURL string
+	}{
+		{"pkg:npm/%40babel/core@7.24.0", "npm", "@babel/core", "7.24.0", ""},
+		{"pkg:pypi/requests", "pypi", "requests", "", ""},
+		{"pkg:maven/org.apache.commons/commons-lang3@3.14.0", "maven", "org.apache.commons:commons-lang3", "3.14.0", ""},
+		{"pkg:golang/github.com/spf13/cobra@v1.10.2", "golang", "github.com/spf13/cobra", "v1.10.2", ""},
+		{"pkg:gem/rails@7.1.3", "gem", "rails", "7.1.3", ""},
+		{"pkg:npm/left-pad@1.3.0?repository_url=https://npm.example.com", "npm", "left-pad", "1.3.0", "https://npm.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			c, name, version, err := NewFromPURL(tt.purl)
+			if err != nil {
+				t.Fatalf("NewFromPURL() error = %v", err)
+			}
+			if c.ecosystem != tt.ecosystem || name != tt.wantName || version != tt.wantVersion {
+				t.Errorf("NewFromPURL() = %s %q %q, want %s %q %q", c.ecosystem, name, version, tt.ecosystem, tt.wantName, tt.wantVersion)
+			}
+			if c.baseURL != tt.wantBaseURL {
+				t.Errorf("NewFromPURL() queries %q, want %q", c.baseURL, tt.wantBaseURL)
+			}
+		})
+	}
+}
+
+func TestNewFromPURLInvalid(t *testing.T) {
+	for _, purl := range []string{"express", "pkg:unknown/thing"} {
+		if _, _, _, err := NewFromPURL(purl); err == nil {
+			t.Errorf("NewFromPURL(%q) expected an error", purl)
+		}
+	}
+}
+
+// TestNewFromPURLLikeNew checks that package URLs get the clients New
+// creates, with their GOPROXY and Terraform handling.
+func TestNewFromPURLLikeNew(t *testing.T) {
+	t.Setenv("GOPROXY", "https://goproxy.example.com/,direct")
+
+	c, _, _, err := NewFromPURL("pkg:golang/github.com/spf13/cobra@v1.10.2")
+	if err != nil {
+		t.Fatalf("NewFromPURL() error = %v", err)
+	}
+	if c.baseURL != "https://goproxy.example.com" {
+		t.Errorf("golang client queries %q, want the GOPROXY", c.baseURL)
+	}
+
+	c, _, _, err = NewFromPURL("pkg:terraform/hashicorp/aws@5.47.0")
+	if err != nil {
+		t.Fatalf("NewFromPURL() error = %v", err)
+	}
+	if _, ok := c.reg.(terraformRegistry); !ok {
+		t.Errorf("terraform client uses %T, want terraformRegistry", c.reg)
+	}
+}
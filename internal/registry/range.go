@@ -0,0 +1,56 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/git-pkgs/registries"
+	"github.com/git-pkgs/vers"
+)
+
+// parseRange parses a version range in the native syntax of the client's
+// ecosystem, e.g. "^4.17" for npm or ">=4.2,<5" for PyPI, or as a Package
+// URL "vers:" string. An empty expression yields a nil range.
+func (c *Client) parseRange(expr string) (*vers.Range, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, nil
+	}
+
+	var (
+		r   *vers.Range
+		err error
+	)
+	if strings.HasPrefix(expr, "vers:") {
+		r, err = vers.Parse(expr)
+	} else {
+		r, err = vers.ParseNative(expr, nativeScheme(c.ecosystem))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid version range '%s': %w", expr, err)
+	}
+	return r, nil
+}
+
+// nativeScheme names the range syntax of an ecosystem. Terraform version
+// constraints share the RubyGems syntax, "~>" included.
+func nativeScheme(ecosystem string) string {
+	if ecosystem == "terraform" {
+		return "gem"
+	}
+	return ecosystem
+}
+
+// filterByRange keeps the versions within r. A nil range keeps them all.
+func filterByRange(versions []registries.Version, r *vers.Range) []registries.Version {
+	if r == nil {
+		return versions
+	}
+	filtered := make([]registries.Version, 0, len(versions))
+	for _, v := range versions {
+		if r.Contains(v.Number) {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
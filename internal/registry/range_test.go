@@ -0,0 +1,96 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/git-pkgs/registries"
+)
+
+func TestFilterByRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		ecosystem string
+		expr      string
+		versions  []string
+		want      []string
+	}{
+		{
+			name:      "npm caret",
+			ecosystem: "npm",
+			expr:      "^4.17",
+			versions:  []string{"4.16.6", "4.17.0", "4.17.21", "5.0.0"},
+			want:      []string{"4.17.0", "4.17.21"},
+		},
+		{
+			name:      "pypi specifiers",
+			ecosystem: "pypi",
+			expr:      ">=4.2,<5",
+			versions:  []string{"4.1.13", "4.2", "4.2.16", "5.0"},
+			want:      []string{"4.2", "4.2.16"},
+		},
+		{
+			name:      "cargo tilde",
+			ecosystem: "cargo",
+			expr:      "~1.0.100",
+			versions:  []string{"1.0.99", "1.0.100", "1.0.219", "1.1.0"},
+			want:      []string{"1.0.100", "1.0.219"},
+		},
+		{
+			name:      "gem pessimistic",
+			ecosystem: "gem",
+			expr:      "~> 7.1.0",
+			versions:  []string{"7.0.8", "7.1.0", "7.1.5", "7.2.0"},
+			want:      []string{"7.1.0", "7.1.5"},
+		},
+		{
+			name:      "terraform pessimistic",
+			ecosystem: "terraform",
+			expr:      "~> 5.0",
+			versions:  []string{"4.67.0", "5.0.0", "5.47.0", "6.0.0"},
+			want:      []string{"5.0.0", "5.47.0"},
+		},
+		{
+			name:      "vers string",
+			ecosystem: "npm",
+			expr:      "vers:npm/>=1.0.0|<2.0.0",
+			versions:  []string{"0.9.0", "1.0.0", "1.9.9", "2.0.0"},
+			want:      []string{"1.0.0", "1.9.9"},
+		},
+		{
+			name:      "empty range keeps everything",
+			ecosystem: "npm",
+			versions:  []string{"1.0.0", "2.0.0"},
+			want:      []string{"1.0.0", "2.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{ecosystem: tt.ecosystem}
+			rng, err := c.parseRange(tt.expr)
+			if err != nil {
+				t.Fatalf("parseRange(%q) error = %v", tt.expr, err)
+			}
+			var versions []registries.Version
+			for _, n := range tt.versions {
+				versions = append(versions, v(n, time.Time{}, ""))
+			}
+			got := numbers(filterByRange(versions, rng))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRangeInvalid(t *testing.T) {
+	c := &Client{ecosystem: "npm"}
+	if _, err := c.parseRange("vers:npm"); err == nil {
+		t.Error("expected error for a malformed vers string")
+	}
+}
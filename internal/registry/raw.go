@@ -0,0 +1,162 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/git-pkgs/registries"
+)
+
+// rawPackage and rawVersion are the JSON shapes of the normalized registry
+// data, including the registry-specific metadata the other commands drop.
+type rawPackage struct {
+	Name          string         `json:"name"`
+	Description   string         `json:"description"`
+	Homepage      string         `json:"homepage"`
+	Repository    string         `json:"repository"`
+	Licenses      string         `json:"licenses"`
+	Keywords      []string       `json:"keywords"`
+	Namespace     string         `json:"namespace"`
+	LatestVersion string         `json:"latest_version"`
+	Metadata      map[string]any `json:"metadata"`
+}
+
+type rawVersion struct {
+	Number      string         `json:"number"`
+	PublishedAt *time.Time     `json:"published_at"`
+	Licenses    string         `json:"licenses"`
+	Integrity   string         `json:"integrity"`
+	Status      string         `json:"status"`
+	Metadata    map[string]any `json:"metadata"`
+}
+
+func newRawVersion(v registries.Version) rawVersion {
+	raw := rawVersion{
+		Number:    v.Number,
+		Licenses:  v.Licenses,
+		Integrity: v.Integrity,
+		Status:    string(v.Status),
+		Metadata:  v.Metadata,
+	}
+	if !v.PublishedAt.IsZero() {
+		raw.PublishedAt = &v.PublishedAt
+	}
+	return raw
+}
+
+// RawShow returns the normalized package data under "package" and the data
+// of the version show reports under "version", when the registry lists it.
+func (c *Client) RawShow(ctx context.Context, name string, opts Options) (map[string]any, error) {
+	pkg, err := c.reg.FetchPackage(ctx, name)
+	if err != nil {
+		return nil, c.mapError(name, err)
+	}
+
+	raw := map[string]any{
+		"package": rawPackage{
+			Name:          pkg.Name,
+			Description:   pkg.Description,
+			Homepage:      pkg.Homepage,
+			Repository:    pkg.Repository,
+			Licenses:      pkg.Licenses,
+			Keywords:      pkg.Keywords,
+			Namespace:     pkg.Namespace,
+			LatestVersion: pkg.LatestVersion,
+			Metadata:      pkg.Metadata,
+		},
+	}
+
+	// Some registries only list versions separately; the package data is
+	// still worth returning without them.
+	if versions, verr := c.reg.FetchVersions(ctx, name); verr == nil {
+		version, err := c.showVersion(pkg, versions, true, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range versions {
+			if v.Number == version {
+				raw["version"] = newRawVersion(v)
+				break
+			}
+		}
+	}
+	return normalize(raw)
+}
+
+// RawVersions returns the normalized data of every version of a package,
+// including yanked, deprecated and retracted ones.
+func (c *Client) RawVersions(ctx context.Context, name string, opts Options) ([]map[string]any, error) {
+	rng, err := c.parseRange(opts.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := c.reg.FetchVersions(ctx, name)
+	if err != nil {
+		return nil, c.mapError(name, err)
+	}
+
+	versions = filterByMinReleaseAge(versions, opts.MinReleaseAge)
+	versions = filterByRange(versions, rng)
+	raw := make([]map[string]any, 0, len(versions))
+	for _, v := range versions {
+		m, err := normalize(newRawVersion(v))
+		if err != nil {
+			return nil, err
+		}
+		raw = append(raw, m)
+	}
+	return raw, nil
+}
+
+// normalize round-trips v through JSON, so that registry-specific metadata
+// holding typed values can be walked like the rest of the data.
+func normalize(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode registry data: %w", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to decode registry data: %w", err)
+	}
+	return m, nil
+}
+
+// fieldScopes are the nested objects Field descends into, in order, when a
+// path is not found at the current level.
+var fieldScopes = []string{"metadata", "package", "version"}
+
+// Field looks up a dot-separated path such as "dist.tarball" in raw registry
+// data. Paths are resolved from the top level first, then from the package,
+// the version and their metadata, so that "requires_python" finds
+// version.metadata.requires_python.
+func Field(raw map[string]any, path string) (any, bool) {
+	if value, ok := lookup(raw, strings.Split(path, ".")); ok {
+		return value, true
+	}
+	for _, scope := range fieldScopes {
+		if nested, ok := raw[scope].(map[string]any); ok {
+			if value, ok := Field(nested, path); ok {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func lookup(value any, keys []string) (any, bool) {
+	for _, key := range keys {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
@@ -0,0 +1,73 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/git-pkgs/registries"
+)
+
+func TestField(t *testing.T) {
+	published := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	raw, err := normalize(map[string]any{
+		"package": rawPackage{
+			Name:     "requests",
+			Licenses: "Apache-2.0",
+			Metadata: map[string]any{"classifiers": []string{"Typed"}, "urls": map[string]string{"Source": "https://example.com"}},
+		},
+		"version": newRawVersion(registries.Version{
+			Number:      "2.32.3",
+			PublishedAt: published,
+			Metadata:    map[string]any{"requires_python": ">=3.8"},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want any
+		ok   bool
+	}{
+		{"package.name", "requests", true},
+		{"licenses", "Apache-2.0", true},
+		{"urls.Source", "https://example.com", true},
+		{"requires_python", ">=3.8", true},
+		{"version.metadata.requires_python", ">=3.8", true},
+		{"number", "2.32.3", true},
+		{"published_at", "2025-01-31T12:00:00Z", true},
+		{"classifiers", []any{"Typed"}, true},
+		{"rust_version", nil, false},
+		{"package.name.first", nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := Field(raw, tt.path)
+		if ok != tt.ok {
+			t.Errorf("Field(%q) ok = %v, want %v", tt.path, ok, tt.ok)
+			continue
+		}
+		if want, isSlice := tt.want.([]any); isSlice {
+			if s, _ := got.([]any); len(s) != len(want) || s[0] != want[0] {
+				t.Errorf("Field(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Field(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestNewRawVersionOmitsUnknownPublishTime(t *testing.T) {
+	raw, err := normalize(newRawVersion(registries.Version{Number: "1.0.0", Status: registries.StatusYanked}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw["published_at"] != nil {
+		t.Errorf("published_at = %v, want null", raw["published_at"])
+	}
+	if raw["status"] != "yanked" {
+		t.Errorf("status = %v, want yanked", raw["status"])
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/acidghost/a555pq/internal/advisory"
 	"github.com/acidghost/a555pq/internal/formatter"
 	"github.com/git-pkgs/registries"
 	_ "github.com/git-pkgs/registries/all" // register all ecosystems
@@ -16,6 +19,9 @@ import (
 type Client struct {
 	reg       registries.Registry
 	ecosystem string
+	// baseURL is the registry the client queries, empty for the ecosystem's
+	// default.
+	baseURL string
 }
 
 // Options controls optional registry query behavior.
@@ -27,14 +33,52 @@ type Options struct {
 	// publish release timestamps (e.g. homebrew, deno, terraform) are
 	// unaffected.
 	MinReleaseAge time.Duration
+	// SkipVulnerable, when set, is the advisory source Latest checks
+	// versions against, skipping those affected by a known advisory.
+	SkipVulnerable advisory.Source
+	// Strategy decides which version Latest reports. The zero value is
+	// StrategyHighest.
+	Strategy Strategy
+	// Sort is the order Versions lists versions in. The zero value is
+	// SortDate.
+	Sort SortOrder
+	// Version makes Show describe that exact version instead of the latest.
+	Version string
+	// Scopes lists the dependency scopes Show lists and Tree follows, only
+	// runtime when empty.
+	Scopes []registries.Scope
+	// Range restricts Versions and Latest to the versions within a range,
+	// in the ecosystem's native syntax or as a "vers:" string.
+	Range string
+	// All makes Versions include yanked, deprecated and retracted versions,
+	// along with their status and the reason the registry gives for it.
+	All bool
+	// Provenance makes Versions report how each npm version was published,
+	// see Client.Provenance.
+	Provenance bool
 }
 
+// New creates a client for the public registry of an ecosystem. Go modules
+// are queried through the proxy GOPROXY names, when it names one.
 func New(ecosystem string) (*Client, error) {
-	reg, err := registries.New(ecosystem, "", nil)
+	return newClientAt(ecosystem, "")
+}
+
+// newClientAt creates a client querying the registry at baseURL, or the
+// ecosystem's default one when empty: for Go modules, the proxy GOPROXY
+// names.
+func newClientAt(ecosystem, baseURL string) (*Client, error) {
+	if ecosystem == "golang" && baseURL == "" {
+		baseURL = goProxy()
+	}
+	reg, err := registries.New(ecosystem, baseURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("unsupported ecosystem: %s", ecosystem)
 	}
-	return &Client{reg: reg, ecosystem: ecosystem}, nil
+	if ecosystem == "terraform" {
+		reg = terraformRegistry{reg}
+	}
+	return &Client{reg: reg, ecosystem: ecosystem, baseURL: baseURL}, nil
 }
 
 func SupportedEcosystems() []string {
@@ -47,19 +91,33 @@ func (c *Client) Show(ctx context.Context, name string, opts Options) (*formatte
 		return nil, c.mapError(name, err)
 	}
 
-	// Resolve the version to report. When a minimum release age is requested,
-	// prefer the newest version older than the cutoff instead of the registry's
-	// reported latest. Fall back to the registry latest when timestamps are
-	// unavailable or fetching versions fails.
-	version := pkg.LatestVersion
-	if opts.MinReleaseAge > 0 {
-		if versions, verr := c.reg.FetchVersions(ctx, name); verr == nil {
-			if selected := selectLatest(filterByMinReleaseAge(versions, opts.MinReleaseAge)); selected != nil {
-				version = selected.Number
-			}
+	versions, verr := c.reg.FetchVersions(ctx, name)
+	version, err := c.showVersion(pkg, versions, verr == nil, opts)
+	if err != nil {
+		return nil, err
+	}
+	var info registries.Version
+	for _, v := range versions {
+		if v.Number == version {
+			info = v
+			break
 		}
 	}
 
+	// Tell when the reported version or the whole package should no longer
+	// be used, which otherwise only shows up as install-time warnings. Go
+	// modules record both their deprecation and why versions are retracted
+	// in go.mod, which is fetched for Go modules only.
+	var warnings []string
+	notes := c.goModNotes(ctx, name, versions)
+	if status := versionStatus(info, versionReason(info, notes)); status != "" {
+		warnings = append(warnings, status)
+	}
+	deprecation, replacement := packageDeprecation(pkg, notes)
+	if deprecation != "" {
+		warnings = append(warnings, deprecation)
+	}
+
 	var author, authorEmail string
 	maintainers, merr := c.reg.FetchMaintainers(ctx, name)
 	if merr == nil && len(maintainers) > 0 {
@@ -74,48 +132,124 @@ func (c *Client) Show(ctx context.Context, name string, opts Options) (*formatte
 	deps, derr := c.reg.FetchDependencies(ctx, name, version)
 	if derr == nil {
 		for _, dep := range deps {
-			if dep.Scope == registries.Runtime {
+			if includesDependency(opts.Scopes, dep) {
 				dependencies = append(dependencies, dependencyString(dep))
 			}
 		}
 	}
 
+	// Versions may carry their own license, e.g. after a relicensing.
+	license := pkg.Licenses
+	if info.Licenses != "" {
+		license = info.Licenses
+	}
+	var uploadDate string
+	if !info.PublishedAt.IsZero() {
+		uploadDate = info.PublishedAt.Format("2006-01-02 15:04:05")
+	}
+
 	return &formatter.ShowOutput{
 		Name:         pkg.Name,
 		Version:      version,
+		UploadDate:   uploadDate,
 		Description:  pkg.Description,
 		Author:       author,
 		AuthorEmail:  authorEmail,
-		License:      pkg.Licenses,
+		License:      license,
 		HomePage:     pkg.Homepage,
+		Integrity:    info.Integrity,
+		Status:       string(info.Status),
 		Dependencies: dependencies,
+		Warnings:     warnings,
+		Replacement:  replacement,
 	}, nil
 }
 
+// showVersion resolves the version show reports. An explicit version must
+// exist when the registry lists versions. Otherwise, when a minimum release
+// age is requested, the newest version older than the cutoff is preferred
+// over the registry's reported latest. The registry latest is kept when
+// timestamps are unavailable or fetching versions failed.
+func (c *Client) showVersion(pkg *registries.Package, versions []registries.Version, listed bool, opts Options) (string, error) {
+	if opts.Version != "" {
+		if listed && !slices.ContainsFunc(versions, func(v registries.Version) bool { return v.Number == opts.Version }) {
+			return "", fmt.Errorf("version '%s' of package '%s' not found", opts.Version, pkg.Name)
+		}
+		return opts.Version, nil
+	}
+	if listed && opts.MinReleaseAge > 0 {
+		if selected := c.selectVersion(filterByMinReleaseAge(versions, opts.MinReleaseAge), StrategyRegistry, pkg.LatestVersion); selected != nil {
+			return selected.Number, nil
+		}
+	}
+	return pkg.LatestVersion, nil
+}
+
+// includesDependency tells whether dep belongs to one of scopes, runtime
+// when none are given. Optional dependencies of any scope, such as PyPI
+// extras, are only included with registries.Optional. Show and Tree share
+// this rule.
+func includesDependency(scopes []registries.Scope, dep registries.Dependency) bool {
+	if len(scopes) == 0 {
+		scopes = []registries.Scope{registries.Runtime}
+	}
+	if dep.Optional {
+		return slices.Contains(scopes, registries.Optional)
+	}
+	return slices.Contains(scopes, dep.Scope)
+}
+
+// dependencyString labels dependencies outside the runtime scope with their
+// scope, and optional ones as such.
 func dependencyString(d registries.Dependency) string {
-	scope := ""
+	var labels []string
 	if d.Scope != registries.Runtime {
-		scope = string(d.Scope)
+		labels = append(labels, string(d.Scope))
 	}
-	opt := ""
-	if d.Optional {
-		opt = ",opt"
+	if d.Optional && d.Scope != registries.Optional {
+		labels = append(labels, "opt")
 	}
-	extra := ""
-	if scope != "" || opt != "" {
-		extra = " (" + scope + opt + ")"
+	if len(labels) == 0 {
+		return d.Name + d.Requirements
 	}
-	return d.Name + d.Requirements + extra
+	return d.Name + d.Requirements + " (" + strings.Join(labels, ",") + ")"
 }
 
 func (c *Client) Latest(ctx context.Context, name string, opts Options) (*formatter.LatestOutput, error) {
+	rng, err := c.parseRange(opts.Range)
+	if err != nil {
+		return nil, err
+	}
+
 	versions, err := c.reg.FetchVersions(ctx, name)
 	if err != nil {
 		return nil, c.mapError(name, err)
 	}
 
 	versions = filterByMinReleaseAge(versions, opts.MinReleaseAge)
-	ver := selectLatest(versions)
+	versions = filterByRange(versions, rng)
+	if opts.SkipVulnerable != nil {
+		vulns, ecosystem, err := c.vulnerabilities(ctx, name, opts.SkipVulnerable)
+		if err != nil {
+			return nil, err
+		}
+		versions = c.filterVulnerable(versions, vulns, ecosystem, name)
+	}
+	var registryLatest string
+	if opts.Strategy == StrategyRegistry {
+		pkg, err := c.reg.FetchPackage(ctx, name)
+		if err != nil {
+			return nil, c.mapError(name, err)
+		}
+		registryLatest = pkg.LatestVersion
+	}
+	ver := c.selectVersion(versions, opts.Strategy, registryLatest)
+	if ver == nil && rng != nil {
+		return nil, fmt.Errorf("no versions matching '%s' found for package '%s'", opts.Range, name)
+	}
+	if ver == nil && opts.SkipVulnerable != nil {
+		return nil, fmt.Errorf("no versions without known advisories found for package '%s'", name)
+	}
 	if ver == nil {
 		return nil, fmt.Errorf("no versions found for package '%s'", name)
 	}
@@ -127,28 +261,52 @@ func (c *Client) Latest(ctx context.Context, name string, opts Options) (*format
 }
 
 func (c *Client) Versions(ctx context.Context, name string, opts Options) (*formatter.VersionsOutput, error) {
+	rng, err := c.parseRange(opts.Range)
+	if err != nil {
+		return nil, err
+	}
+
 	versions, err := c.reg.FetchVersions(ctx, name)
 	if err != nil {
 		return nil, c.mapError(name, err)
 	}
 
+	var keys []npmKey
+	if opts.Provenance {
+		if c.ecosystem != "npm" {
+			return nil, fmt.Errorf("provenance is only published by npm")
+		}
+		if keys, err = c.npmKeys(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	versions = filterByMinReleaseAge(versions, opts.MinReleaseAge)
+	versions = filterByRange(versions, rng)
+	c.sortVersions(versions, opts.Sort)
+
+	var notes *goModNotes
+	if opts.All && slices.ContainsFunc(versions, func(v registries.Version) bool { return v.Status == registries.StatusRetracted }) {
+		notes = c.goModNotes(ctx, name, versions)
+	}
 
 	items := make([]formatter.VersionItem, 0, len(versions))
 	for _, v := range versions {
-		if v.Status != "" {
+		if v.Status != "" && !opts.All {
 			continue
 		}
-		items = append(items, formatter.VersionItem{
+		item := formatter.VersionItem{
 			Version:    v.Number,
 			UploadDate: v.PublishedAt.Format("2006-01-02 15:04:05"),
-		})
+			Status:     string(v.Status),
+			Reason:     versionReason(v, notes),
+		}
+		if opts.Provenance {
+			item.Provenance = provenanceLabel(name, v, keys)
+		}
+		items = append(items, item)
 	}
 
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].UploadDate > items[j].UploadDate
-	})
-
 	return &formatter.VersionsOutput{
 		Package:  name,
 		Versions: items,
@@ -160,16 +318,20 @@ func (c *Client) BrowseURL(name string) string {
 }
 
 func (c *Client) mapError(name string, err error) error {
-	var httpErr *client.HTTPError
-	if errors.As(err, &httpErr) && httpErr.StatusCode == 404 {
-		return fmt.Errorf("package '%s' not found", name)
-	}
-	if errors.Is(err, client.ErrNotFound) {
+	if isNotFound(err) {
 		return fmt.Errorf("package '%s' not found", name)
 	}
 	return err
 }
 
+func isNotFound(err error) bool {
+	var httpErr *client.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == 404 {
+		return true
+	}
+	return errors.Is(err, client.ErrNotFound)
+}
+
 // filterByMinReleaseAge removes versions published more recently than minAge
 // relative to the current time. Versions without a known publish time are kept.
 func filterByMinReleaseAge(versions []registries.Version, minAge time.Duration) []registries.Version {
@@ -145,3 +145,28 @@ func numbers(vs []registries.Version) []string {
 	}
 	return out
 }
+
+func TestIncludesDependency(t *testing.T) {
+	runtime := registries.Dependency{Name: "a", Scope: registries.Runtime}
+	optional := registries.Dependency{Name: "b", Scope: registries.Runtime, Optional: true}
+	development := registries.Dependency{Name: "c", Scope: registries.Development}
+
+	tests := []struct {
+		scopes []registries.Scope
+		dep    registries.Dependency
+		want   bool
+	}{
+		{nil, runtime, true},
+		{nil, optional, false},
+		{nil, development, false},
+		{[]registries.Scope{registries.Runtime}, optional, false},
+		{[]registries.Scope{registries.Optional}, optional, true},
+		{[]registries.Scope{registries.Optional}, runtime, false},
+		{[]registries.Scope{registries.Development}, development, true},
+	}
+	for _, tt := range tests {
+		if got := includesDependency(tt.scopes, tt.dep); got != tt.want {
+			t.Errorf("includesDependency(%v, %s) = %v, want %v", tt.scopes, tt.dep.Name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/git-pkgs/registries"
+)
+
+// showRegistry adds package data to fakeRegistry.
+type showRegistry struct {
+	*fakeRegistry
+}
+
+func (r showRegistry) FetchPackage(_ context.Context, name string) (*registries.Package, error) {
+	return &registries.Package{Name: name, LatestVersion: "2.0.0", Licenses: "MIT"}, nil
+}
+
+func (r showRegistry) FetchMaintainers(context.Context, string) ([]registries.Maintainer, error) {
+	return nil, nil
+}
+
+func TestShowVersion(t *testing.T) {
+	reg := newFakeTreeRegistry()
+	reg.deps["left@1.1.0"] = []registries.Dependency{
+		dep("shared", "^1.2.0"),
+		{Name: "tool", Requirements: "^3.0.0", Scope: registries.Development},
+		{Name: "extra", Requirements: ">=1", Scope: registries.Runtime, Optional: true},
+		{Name: "lint", Requirements: "*", Scope: registries.Test},
+	}
+	c := &Client{reg: showRegistry{reg}, ecosystem: "npm"}
+
+	tests := []struct {
+		name    string
+		opts    Options
+		version string
+		deps    []string
+	}{
+		{
+			name:    "latest with runtime dependencies",
+			version: "2.0.0",
+		},
+		{
+			name:    "exact version",
+			opts:    Options{Version: "1.1.0"},
+			version: "1.1.0",
+			deps:    []string{"shared^1.2.0"},
+		},
+		{
+			name:    "selected scopes",
+			opts:    Options{Version: "1.1.0", Scopes: []registries.Scope{registries.Development, registries.Test}},
+			version: "1.1.0",
+			deps:    []string{"tool^3.0.0 (development)", "lint* (test)"},
+		},
+		{
+			name:    "optional only",
+			opts:    Options{Version: "1.1.0", Scopes: []registries.Scope{registries.Optional}},
+			version: "1.1.0",
+			deps:    []string{"extra>=1 (opt)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := c.Show(context.Background(), "left", tt.opts)
+			if err != nil {
+				t.Fatalf("Show() error = %v", err)
+			}
+			if output.Version != tt.version {
+				t.Errorf("Version = %q, want %q", output.Version, tt.version)
+			}
+			if output.UploadDate == "" {
+				t.Error("expected the upload date of the version")
+			}
+			if !equal(output.Dependencies, tt.deps) {
+				t.Errorf("Dependencies = %v, want %v", output.Dependencies, tt.deps)
+			}
+		})
+	}
+}
+
+func TestShowUnknownVersion(t *testing.T) {
+	c := &Client{reg: showRegistry{newFakeTreeRegistry()}, ecosystem: "npm"}
+
+	_, err := c.Show(context.Background(), "left", Options{Version: "9.9.9"})
+	if err == nil || err.Error() != "version '9.9.9' of package 'left' not found" {
+		t.Errorf("Show() error = %v", err)
+	}
+}
+
+func TestShowDeprecatedModule(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/left/@v/2.0.0.mod" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(testGoMod))
+	}))
+	t.Cleanup(srv.Close)
+	c := &Client{reg: showRegistry{newFakeTreeRegistry()}, ecosystem: "golang", baseURL: srv.URL}
+
+	output, err := c.Show(context.Background(), "left", Options{})
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if output.Status != "" {
+		t.Fatalf("Status = %q, want a version in good standing", output.Status)
+	}
+	if want := "module is deprecated: use example.com/mod/v2 instead."; !slices.Contains(output.Warnings, want) {
+		t.Errorf("Warnings = %v, want %q", output.Warnings, want)
+	}
+	if output.Replacement != "example.com/mod/v2" {
+		t.Errorf("Replacement = %q, want example.com/mod/v2", output.Replacement)
+	}
+}
@@ -0,0 +1,139 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/git-pkgs/registries"
+	"github.com/git-pkgs/vers"
+)
+
+// statusReason returns why a version was yanked, deprecated or retracted,
+// as recorded in its registry-specific metadata: the npm deprecation
+// message, the PyPI yanked reason, the cargo yank message, the Hex
+// retirement and the NuGet deprecation. It is empty when the registry gives
+// no reason.
+func statusReason(v registries.Version) string {
+	if v.Status == registries.StatusNone {
+		return ""
+	}
+	raw, err := normalize(newRawVersion(v))
+	if err != nil {
+		return ""
+	}
+	metadata, _ := raw["metadata"].(map[string]any)
+
+	for _, key := range []string{"deprecated", "yanked_reason", "yank_message"} {
+		// npm marks deprecations without a message with a bare true.
+		if reason, ok := metadata[key].(string); ok && reason != "" && reason != "true" {
+			return reason
+		}
+	}
+	if retirement, ok := metadata["retirement"].(map[string]any); ok {
+		return joinReason(retirement["reason"], retirement["message"])
+	}
+	if deprecation, ok := metadata["deprecation"].(map[string]any); ok {
+		var reasons []string
+		if list, ok := deprecation["reasons"].([]any); ok {
+			for _, r := range list {
+				if s, ok := r.(string); ok {
+					reasons = append(reasons, s)
+				}
+			}
+		}
+		return joinReason(strings.Join(reasons, ", "), deprecation["message"])
+	}
+	return ""
+}
+
+// joinReason formats a reason code and a free-form message as
+// "code: message", leaving out whichever is missing.
+func joinReason(code, message any) string {
+	var parts []string
+	for _, part := range []any{code, message} {
+		if s, ok := part.(string); ok && s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ": ")
+}
+
+// versionReason is the statusReason of v, falling back to the retraction
+// rationale of Go modules, which only their go.mod records.
+func versionReason(v registries.Version, notes *goModNotes) string {
+	reason := statusReason(v)
+	if reason == "" && notes != nil && v.Status == registries.StatusRetracted {
+		reason = notes.rationale(v.Number)
+	}
+	return reason
+}
+
+// packageDeprecation tells whether the whole package is deprecated, with the
+// suggested replacement when the registry provides one. Packagist records
+// abandoned packages; Go modules are deprecated by a comment in go.mod,
+// which conventionally names the replacement as "use <module>".
+func packageDeprecation(pkg *registries.Package, notes *goModNotes) (message, replacement string) {
+	switch abandoned := pkg.Metadata["abandoned"].(type) {
+	case string:
+		return "package is abandoned", abandoned
+	case bool:
+		if abandoned {
+			return "package is abandoned", ""
+		}
+	}
+	if notes != nil && notes.Deprecated != "" {
+		return "module is deprecated: " + notes.Deprecated, goReplacement(notes.Deprecated)
+	}
+	return "", ""
+}
+
+// goReplacement extracts the module a deprecation comment points to, as in
+// "Use example.com/mod/v2 instead.", or returns "".
+func goReplacement(deprecated string) string {
+	fields := strings.Fields(deprecated)
+	if len(fields) < 2 || !strings.EqualFold(fields[0], "use") {
+		return ""
+	}
+	return strings.TrimRight(fields[1], ".,;:")
+}
+
+// goModNotes reads the deprecation and retractions of a Go module from the
+// go.mod of its highest version, retracted ones included, as the go command
+// does. It returns nil for other ecosystems and when go.mod cannot be
+// fetched; the notes are only informative.
+func (c *Client) goModNotes(ctx context.Context, name string, versions []registries.Version) *goModNotes {
+	if c.ecosystem != "golang" {
+		return nil
+	}
+	var latest string
+	for _, v := range versions {
+		if latest == "" || vers.CompareWithScheme(v.Number, latest, c.ecosystem) > 0 {
+			latest = v.Number
+		}
+	}
+	if latest == "" {
+		return nil
+	}
+
+	gomod, err := c.fetchGoMod(ctx, name, latest)
+	if err != nil {
+		return nil
+	}
+	notes := parseGoModNotes(gomod)
+	return &notes
+}
+
+// versionStatus describes the status of version for show, e.g.
+// "version 1.2.3 is deprecated: use 2.x", or returns "" when the version is
+// in good standing.
+func versionStatus(v registries.Version, reason string) string {
+	if v.Status == registries.StatusNone {
+		return ""
+	}
+	status := fmt.Sprintf("version %s is %s", v.Number, v.Status)
+	if reason != "" {
+		status += ": " + reason
+	}
+	return status
+}
@@ -0,0 +1,89 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/git-pkgs/registries"
+)
+
+func TestStatusReason(t *testing.T) {
+	// nugetDeprecation mirrors the shape the NuGet adapter stores.
+	type nugetDeprecation struct {
+		Message string   `json:"message"`
+		Reasons []string `json:"reasons"`
+	}
+
+	tests := []struct {
+		name    string
+		version registries.Version
+		want    string
+	}{
+		{
+			name:    "npm deprecation message",
+			version: registries.Version{Status: registries.StatusDeprecated, Metadata: map[string]any{"deprecated": "use foo@2 instead"}},
+			want:    "use foo@2 instead",
+		},
+		{
+			name:    "npm deprecation without message",
+			version: registries.Version{Status: registries.StatusDeprecated, Metadata: map[string]any{"deprecated": "true"}},
+			want:    "",
+		},
+		{
+			name:    "pypi yanked reason",
+			version: registries.Version{Status: registries.StatusYanked, Metadata: map[string]any{"yanked_reason": "broken wheel", "requires_python": ">=3.8"}},
+			want:    "broken wheel",
+		},
+		{
+			name:    "cargo yank message",
+			version: registries.Version{Status: registries.StatusYanked, Metadata: map[string]any{"yank_message": "unsound", "rust_version": "1.60"}},
+			want:    "unsound",
+		},
+		{
+			name:    "hex retirement",
+			version: registries.Version{Status: registries.StatusRetracted, Metadata: map[string]any{"retirement": map[string]any{"reason": "security", "message": "CVE-2024-1"}}},
+			want:    "security: CVE-2024-1",
+		},
+		{
+			name:    "nuget deprecation",
+			version: registries.Version{Status: registries.StatusDeprecated, Metadata: map[string]any{"deprecation": &nugetDeprecation{Message: "moved", Reasons: []string{"Legacy"}}}},
+			want:    "Legacy: moved",
+		},
+		{
+			name:    "good standing",
+			version: registries.Version{Metadata: map[string]any{"deprecated": ""}},
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusReason(tt.version); got != tt.want {
+				t.Errorf("statusReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackageDeprecation(t *testing.T) {
+	tests := []struct {
+		name            string
+		metadata        map[string]any
+		notes           *goModNotes
+		wantMessage     string
+		wantReplacement string
+	}{
+		{"abandoned with replacement", map[string]any{"abandoned": "guzzlehttp/guzzle"}, nil, "package is abandoned", "guzzlehttp/guzzle"},
+		{"abandoned", map[string]any{"abandoned": true}, nil, "package is abandoned", ""},
+		{"maintained", map[string]any{"abandoned": nil}, nil, "", ""},
+		{"deprecated module", nil, &goModNotes{Deprecated: "use example.com/mod/v2"}, "module is deprecated: use example.com/mod/v2", "example.com/mod/v2"},
+		{"deprecated module without replacement", nil, &goModNotes{Deprecated: "no longer maintained."}, "module is deprecated: no longer maintained.", ""},
+		{"deprecated module, sentence", nil, &goModNotes{Deprecated: "Use example.com/new instead."}, "module is deprecated: Use example.com/new instead.", "example.com/new"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, replacement := packageDeprecation(&registries.Package{Metadata: tt.metadata}, tt.notes)
+			if message != tt.wantMessage || replacement != tt.wantReplacement {
+				t.Errorf("packageDeprecation() = %q, %q, want %q, %q", message, replacement, tt.wantMessage, tt.wantReplacement)
+			}
+		})
+	}
+}
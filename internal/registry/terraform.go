@@ -0,0 +1,44 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/git-pkgs/registries"
+	"github.com/git-pkgs/registries/client"
+)
+
+// terraformURL is the registry terraform providers are queried through.
+const terraformURL = "https://registry.terraform.io"
+
+// terraformRegistry extends the terraform module registry to providers,
+// named "namespace/type" as in .terraform.lock.hcl, e.g. "hashicorp/aws".
+// Module names keep their "namespace/name/provider" form.
+type terraformRegistry struct {
+	registries.Registry
+}
+
+type providerVersionsResponse struct {
+	Versions []struct {
+		Version string `json:"version"`
+	} `json:"versions"`
+}
+
+func (r terraformRegistry) FetchVersions(ctx context.Context, name string) ([]registries.Version, error) {
+	if strings.Count(name, "/") != 1 {
+		return r.Registry.FetchVersions(ctx, name)
+	}
+
+	url := fmt.Sprintf("%s/v1/providers/%s/versions", terraformURL, name)
+	var resp providerVersionsResponse
+	if err := client.DefaultClient().GetJSON(ctx, url, &resp); err != nil {
+		return nil, err
+	}
+
+	versions := make([]registries.Version, 0, len(resp.Versions))
+	for _, v := range resp.Versions {
+		versions = append(versions, registries.Version{Number: v.Version})
+	}
+	return versions, nil
+}
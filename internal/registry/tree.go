@@ -0,0 +1,342 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/git-pkgs/registries"
+)
+
+// DefaultTreeConcurrency is the number of registry requests Tree runs at
+// once unless told otherwise.
+const DefaultTreeConcurrency = 16
+
+// TreeOptions controls dependency tree resolution.
+type TreeOptions struct {
+	// MinReleaseAge and Strategy apply to the root package when no version
+	// is given, and MinReleaseAge to every resolved dependency as well.
+	// Scopes lists the dependency scopes to follow, e.g. registries.Runtime
+	// and registries.Development. Optional dependencies are only followed
+	// with registries.Optional, whatever their scope. Scopes other than
+	// runtime and optional only apply to the root package: as with package
+	// managers, the development dependencies of a dependency are not
+	// installed.
+	Options
+	// Depth limits how many levels of dependencies are resolved. Zero
+	// means no limit.
+	Depth int
+	// Concurrency bounds the registry requests running at once. Zero means
+	// DefaultTreeConcurrency.
+	Concurrency int
+}
+
+// SplitPackageVersion splits "name@version" into its parts. A leading "@",
+// as in npm scopes, is part of the name.
+func SplitPackageVersion(spec string) (name, version string) {
+	if i := strings.LastIndex(spec, "@"); i > 0 {
+		return spec[:i], spec[i+1:]
+	}
+	return spec, ""
+}
+
+// Tree resolves the transitive dependencies of a package. Each requirement
+// resolves to the highest matching version, fetched concurrently with every
+// package and version fetched once. A package reached again is listed
+// without its dependencies and marked deduplicated, or marked as a cycle
+// when it depends on itself.
+func (c *Client) Tree(ctx context.Context, name, version string, opts TreeOptions) (*formatter.TreeOutput, error) {
+	r := newResolver(c, opts)
+
+	if version == "" {
+		versions, err := r.versions(ctx, name)
+		if err != nil {
+			return nil, c.mapError(name, err)
+		}
+		ver := c.selectVersion(filterByMinReleaseAge(versions, opts.MinReleaseAge), opts.Strategy, "")
+		if ver == nil {
+			return nil, fmt.Errorf("no versions found for package '%s'", name)
+		}
+		version = ver.Number
+	}
+
+	root := packageKey{name, version}
+	r.root = root
+	if _, err := r.dependencies(ctx, root); err != nil {
+		return nil, c.mapError(name, err)
+	}
+	graph := r.resolve(ctx, root)
+
+	b := &treeBuilder{graph: graph, depth: opts.Depth, expanded: make(map[packageKey]bool), unique: make(map[packageKey]bool)}
+	node := b.build(root, nil, 0)
+	delete(b.unique, root)
+
+	return &formatter.TreeOutput{
+		Root: node,
+		Summary: formatter.TreeSummary{
+			UniquePackages: len(b.unique),
+			MaxDepth:       b.maxDepth,
+		},
+	}, nil
+}
+
+type packageKey struct {
+	name, version string
+}
+
+func (k packageKey) String() string {
+	return k.name + "@" + k.version
+}
+
+// resolvedPackage is a package whose dependencies were fetched and resolved.
+type resolvedPackage struct {
+	edges []treeEdge
+	err   error
+}
+
+type treeEdge struct {
+	dep     registries.Dependency
+	version string
+	err     error
+}
+
+// memo runs fetch once per key, however many goroutines ask for it.
+type memo[T any] struct {
+	mu      sync.Mutex
+	entries map[string]*memoEntry[T]
+}
+
+type memoEntry[T any] struct {
+	once  sync.Once
+	value T
+	err   error
+}
+
+func (m *memo[T]) get(key string, fetch func() (T, error)) (T, error) {
+	m.mu.Lock()
+	if m.entries == nil {
+		m.entries = make(map[string]*memoEntry[T])
+	}
+	e, ok := m.entries[key]
+	if !ok {
+		e = &memoEntry[T]{}
+		m.entries[key] = e
+	}
+	m.mu.Unlock()
+
+	e.once.Do(func() { e.value, e.err = fetch() })
+	return e.value, e.err
+}
+
+type resolver struct {
+	c    *Client
+	opts TreeOptions
+	// root is the package the tree is resolved for.
+	root packageKey
+	// sem bounds the registry requests in flight.
+	sem      chan struct{}
+	allVers  memo[[]registries.Version]
+	allDeps  memo[[]registries.Dependency]
+	resolved memo[string]
+}
+
+func newResolver(c *Client, opts TreeOptions) *resolver {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultTreeConcurrency
+	}
+	return &resolver{c: c, opts: opts, sem: make(chan struct{}, concurrency)}
+}
+
+func (r *resolver) versions(ctx context.Context, name string) ([]registries.Version, error) {
+	return r.allVers.get(name, func() ([]registries.Version, error) {
+		r.sem <- struct{}{}
+		defer func() { <-r.sem }()
+		return r.c.reg.FetchVersions(ctx, name)
+	})
+}
+
+// dependencies fetches the dependencies of a package in the followed
+// scopes, sorted by name.
+func (r *resolver) dependencies(ctx context.Context, key packageKey) ([]registries.Dependency, error) {
+	return r.allDeps.get(key.String(), func() ([]registries.Dependency, error) {
+		r.sem <- struct{}{}
+		deps, err := r.c.reg.FetchDependencies(ctx, key.name, key.version)
+		<-r.sem
+		if err != nil {
+			return nil, err
+		}
+
+		var followed []registries.Dependency
+		for _, dep := range deps {
+			if r.follows(dep, key == r.root) {
+				followed = append(followed, dep)
+			}
+		}
+		slices.SortFunc(followed, func(a, b registries.Dependency) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		return followed, nil
+	})
+}
+
+// follows tells whether dep is part of the tree. Below the root, only
+// runtime and optional dependencies are.
+func (r *resolver) follows(dep registries.Dependency, root bool) bool {
+	if !root && dep.Scope != registries.Runtime && dep.Scope != registries.Optional {
+		return false
+	}
+	return includesDependency(r.opts.Scopes, dep)
+}
+
+// version resolves a requirement to the highest matching version old
+// enough for the minimum release age. Requirements the ecosystem's range
+// syntax does not cover only match a version of that exact name.
+func (r *resolver) version(ctx context.Context, dep registries.Dependency) (string, error) {
+	return r.resolved.get(dep.Name+" "+dep.Requirements, func() (string, error) {
+		versions, err := r.versions(ctx, dep.Name)
+		if err != nil {
+			return "", r.c.mapError(dep.Name, err)
+		}
+		versions = filterByMinReleaseAge(versions, r.opts.MinReleaseAge)
+
+		rng, err := r.c.parseRange(dep.Requirements)
+		if err != nil {
+			for _, v := range versions {
+				if v.Number == dep.Requirements {
+					return v.Number, nil
+				}
+			}
+			return "", fmt.Errorf("unsupported requirement '%s'", dep.Requirements)
+		}
+		if ver := r.c.selectHighest(filterByRange(versions, rng)); ver != nil {
+			return ver.Number, nil
+		}
+		return "", fmt.Errorf("no version matches '%s'", dep.Requirements)
+	})
+}
+
+// resolve walks the dependency graph breadth first, one level at a time,
+// resolving the packages of a level concurrently. Packages are expanded at
+// the shallowest depth they appear at, so the depth limit cuts the graph
+// the same way it cuts the printed tree.
+func (r *resolver) resolve(ctx context.Context, root packageKey) map[packageKey]*resolvedPackage {
+	graph := make(map[packageKey]*resolvedPackage)
+	seen := map[packageKey]bool{root: true}
+	level := []packageKey{root}
+
+	for depth := 0; len(level) > 0 && (r.opts.Depth <= 0 || depth < r.opts.Depth); depth++ {
+		results := make([]*resolvedPackage, len(level))
+		var wg sync.WaitGroup
+		for i, key := range level {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = r.expand(ctx, key)
+			}()
+		}
+		wg.Wait()
+
+		var next []packageKey
+		for i, key := range level {
+			graph[key] = results[i]
+			for _, edge := range results[i].edges {
+				child := packageKey{edge.dep.Name, edge.version}
+				if edge.err == nil && !seen[child] {
+					seen[child] = true
+					next = append(next, child)
+				}
+			}
+		}
+		level = next
+	}
+	return graph
+}
+
+func (r *resolver) expand(ctx context.Context, key packageKey) *resolvedPackage {
+	deps, err := r.dependencies(ctx, key)
+	if err != nil {
+		return &resolvedPackage{err: err}
+	}
+
+	edges := make([]treeEdge, len(deps))
+	var wg sync.WaitGroup
+	for i, dep := range deps {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			version, err := r.version(ctx, dep)
+			edges[i] = treeEdge{dep: dep, version: version, err: err}
+		}()
+	}
+	wg.Wait()
+	return &resolvedPackage{edges: edges}
+}
+
+// treeBuilder turns the resolved graph into a tree, depth first, listing
+// the dependencies of each package only at its first occurrence.
+type treeBuilder struct {
+	graph    map[packageKey]*resolvedPackage
+	depth    int
+	expanded map[packageKey]bool
+	unique   map[packageKey]bool
+	maxDepth int
+}
+
+func (b *treeBuilder) build(key packageKey, ancestors []packageKey, depth int) formatter.TreeNode {
+	node := formatter.TreeNode{Name: key.name, Version: key.version}
+	b.unique[key] = true
+	b.maxDepth = max(b.maxDepth, depth)
+
+	switch {
+	case slices.Contains(ancestors, key):
+		node.Cycle = true
+		return node
+	case b.expanded[key]:
+		node.Deduped = len(b.graph[key].edges) > 0
+		return node
+	}
+
+	pkg, ok := b.graph[key]
+	if !ok || (b.depth > 0 && depth >= b.depth) {
+		return node
+	}
+	b.expanded[key] = true
+	if pkg.err != nil {
+		node.Error = pkg.err.Error()
+		return node
+	}
+
+	ancestors = append(ancestors, key)
+	for _, edge := range pkg.edges {
+		if edge.err != nil {
+			node.Dependencies = append(node.Dependencies, formatter.TreeNode{
+				Name:        edge.dep.Name,
+				Requirement: edge.dep.Requirements,
+				Scope:       scopeLabel(edge.dep),
+				Error:       edge.err.Error(),
+			})
+			continue
+		}
+		child := b.build(packageKey{edge.dep.Name, edge.version}, ancestors, depth+1)
+		child.Requirement = edge.dep.Requirements
+		child.Scope = scopeLabel(edge.dep)
+		node.Dependencies = append(node.Dependencies, child)
+	}
+	return node
+}
+
+// scopeLabel names the scope of non-runtime dependencies.
+func scopeLabel(dep registries.Dependency) string {
+	switch {
+	case dep.Scope == registries.Runtime && !dep.Optional:
+		return ""
+	case dep.Optional && dep.Scope != registries.Optional:
+		return "optional; " + string(dep.Scope)
+	default:
+		return string(dep.Scope)
+	}
+}
@@ -0,0 +1,224 @@
+package registry
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/acidghost/a555pq/internal/formatter"
+	"github.com/git-pkgs/registries"
+	"github.com/git-pkgs/registries/client"
+)
+
+// fakeRegistry serves versions and dependencies from memory and counts the
+// requests made for each.
+type fakeRegistry struct {
+	registries.Registry
+	versions map[string][]string
+	deps     map[string][]registries.Dependency
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (r *fakeRegistry) count(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.calls == nil {
+		r.calls = make(map[string]int)
+	}
+	r.calls[key]++
+}
+
+func (r *fakeRegistry) FetchVersions(_ context.Context, name string) ([]registries.Version, error) {
+	r.count("versions " + name)
+	numbers, ok := r.versions[name]
+	if !ok {
+		return nil, client.ErrNotFound
+	}
+	versions := make([]registries.Version, 0, len(numbers))
+	for _, n := range numbers {
+		versions = append(versions, registries.Version{Number: n, PublishedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)})
+	}
+	return versions, nil
+}
+
+func (r *fakeRegistry) FetchDependencies(_ context.Context, name, version string) ([]registries.Dependency, error) {
+	r.count("deps " + name + "@" + version)
+	return r.deps[name+"@"+version], nil
+}
+
+func dep(name, req string) registries.Dependency {
+	return registries.Dependency{Name: name, Requirements: req, Scope: registries.Runtime}
+}
+
+func newFakeTreeRegistry() *fakeRegistry {
+	return &fakeRegistry{
+		versions: map[string][]string{
+			"app":    {"1.0.0"},
+			"left":   {"1.0.0", "1.1.0", "2.0.0"},
+			"right":  {"1.0.0"},
+			"shared": {"1.2.0", "1.3.0"},
+			"loop":   {"0.1.0"},
+			"tool":   {"3.0.0"},
+		},
+		deps: map[string][]registries.Dependency{
+			"app@1.0.0": {
+				dep("right", "^1.0.0"),
+				dep("left", "^1.0.0"),
+				{Name: "tool", Requirements: "^3.0.0", Scope: registries.Development},
+				dep("missing", "^1.0.0"),
+			},
+			"left@1.1.0":   {dep("shared", "^1.2.0")},
+			"right@1.0.0":  {dep("shared", "~1.3.0"), dep("loop", "*")},
+			"shared@1.3.0": {dep("loop", "0.1.0")},
+			"loop@0.1.0":   {dep("shared", "^1.0.0")},
+		},
+	}
+}
+
+func TestTree(t *testing.T) {
+	reg := newFakeTreeRegistry()
+	c := &Client{reg: reg, ecosystem: "npm"}
+
+	output, err := c.Tree(context.Background(), "app", "", TreeOptions{})
+	if err != nil {
+		t.Fatalf("Tree() error = %v", err)
+	}
+
+	root := output.Root
+	if root.Name != "app" || root.Version != "1.0.0" {
+		t.Fatalf("root = %s@%s", root.Name, root.Version)
+	}
+	if got := names(root.Dependencies); !equal(got, []string{"left@1.1.0", "missing", "right@1.0.0"}) {
+		t.Fatalf("direct dependencies = %v", got)
+	}
+	if root.Dependencies[1].Error == "" {
+		t.Error("expected an error for the missing package")
+	}
+
+	// left -> shared -> loop -> shared is a cycle.
+	shared := root.Dependencies[0].Dependencies[0]
+	if shared.Name != "shared" || shared.Version != "1.3.0" || shared.Deduped {
+		t.Fatalf("first shared = %+v", shared)
+	}
+	loop := shared.Dependencies[0]
+	if len(loop.Dependencies) != 1 || !loop.Dependencies[0].Cycle {
+		t.Errorf("expected cycle below loop, got %+v", loop)
+	}
+
+	// The diamond through right is listed without its dependencies again.
+	right := root.Dependencies[2]
+	if got := names(right.Dependencies); !equal(got, []string{"loop@0.1.0", "shared@1.3.0"}) {
+		t.Fatalf("right dependencies = %v", got)
+	}
+	for _, d := range right.Dependencies {
+		if !d.Deduped || len(d.Dependencies) != 0 {
+			t.Errorf("expected %s to be deduped, got %+v", d.Name, d)
+		}
+	}
+
+	if output.Summary.UniquePackages != 4 {
+		t.Errorf("UniquePackages = %d, want 4", output.Summary.UniquePackages)
+	}
+
+	for key, n := range reg.calls {
+		if n != 1 {
+			t.Errorf("%s fetched %d times", key, n)
+		}
+	}
+}
+
+func TestTreeScopesAndDepth(t *testing.T) {
+	c := &Client{reg: newFakeTreeRegistry(), ecosystem: "npm"}
+
+	output, err := c.Tree(context.Background(), "app", "1.0.0", TreeOptions{
+		Options: Options{Scopes: []registries.Scope{registries.Runtime, registries.Development}},
+		Depth:   1,
+	})
+	if err != nil {
+		t.Fatalf("Tree() error = %v", err)
+	}
+	deps := output.Root.Dependencies
+	if got := names(deps); !equal(got, []string{"left@1.1.0", "missing", "right@1.0.0", "tool@3.0.0"}) {
+		t.Fatalf("direct dependencies = %v", got)
+	}
+	if deps[3].Scope != "development" {
+		t.Errorf("tool scope = %q", deps[3].Scope)
+	}
+	for _, d := range deps {
+		if len(d.Dependencies) != 0 {
+			t.Errorf("depth 1 expanded %s", d.Name)
+		}
+	}
+	if output.Summary.MaxDepth != 1 {
+		t.Errorf("MaxDepth = %d, want 1", output.Summary.MaxDepth)
+	}
+}
+
+func TestTreeScopesOnlyAtRoot(t *testing.T) {
+	reg := newFakeTreeRegistry()
+	reg.deps["tool@3.0.0"] = []registries.Dependency{
+		dep("loop", "0.1.0"),
+		{Name: "shared", Requirements: "^1.2.0", Scope: registries.Development},
+	}
+	c := &Client{reg: reg, ecosystem: "npm"}
+
+	output, err := c.Tree(context.Background(), "app", "1.0.0", TreeOptions{
+		Options: Options{Scopes: []registries.Scope{registries.Runtime, registries.Development}},
+	})
+	if err != nil {
+		t.Fatalf("Tree() error = %v", err)
+	}
+	var tool *formatter.TreeNode
+	for i, d := range output.Root.Dependencies {
+		if d.Name == "tool" {
+			tool = &output.Root.Dependencies[i]
+		}
+	}
+	if tool == nil {
+		t.Fatalf("development dependency of the root not followed: %v", names(output.Root.Dependencies))
+	}
+	if got := names(tool.Dependencies); !equal(got, []string{"loop@0.1.0"}) {
+		t.Errorf("tool dependencies = %v, want only its runtime ones", got)
+	}
+}
+
+func TestSplitPackageVersion(t *testing.T) {
+	tests := []struct{ spec, name, version string }{
+		{"react", "react", ""},
+		{"react@18.2.0", "react", "18.2.0"},
+		{"@types/node", "@types/node", ""},
+		{"@types/node@20.1.0", "@types/node", "20.1.0"},
+	}
+	for _, tt := range tests {
+		if name, version := SplitPackageVersion(tt.spec); name != tt.name || version != tt.version {
+			t.Errorf("SplitPackageVersion(%q) = %q, %q", tt.spec, name, version)
+		}
+	}
+}
+
+func names(nodes []formatter.TreeNode) []string {
+	var out []string
+	for _, n := range nodes {
+		if n.Version == "" {
+			out = append(out, n.Name)
+		} else {
+			out = append(out, n.Name+"@"+n.Version)
+		}
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
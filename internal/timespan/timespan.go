@@ -102,3 +102,18 @@ func Parse(s string) (time.Duration, error) {
 func isLetter(c byte) bool {
 	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
 }
+
+// formatUnits are the units Format rounds down to, largest first.
+var formatUnits = []string{"y", "mo", "d", "h", "m"}
+
+// Format renders d coarsely in the largest unit it fills, rounded down, e.g.
+// "2y", "3mo" or "5d", so that ages read at a glance. The result parses back
+// with [Parse]. Durations under a minute format as "0m".
+func Format(d time.Duration) string {
+	for _, unit := range formatUnits {
+		if n := d / unitMap[unit]; n > 0 {
+			return strconv.FormatInt(int64(n), 10) + unit
+		}
+	}
+	return "0m"
+}
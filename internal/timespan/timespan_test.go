@@ -66,3 +66,23 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{in: 800 * 24 * time.Hour, want: "2y"},
+		{in: 95 * 24 * time.Hour, want: "3mo"},
+		{in: 29 * 24 * time.Hour, want: "29d"},
+		{in: 5 * time.Hour, want: "5h"},
+		{in: 90 * time.Second, want: "1m"},
+		{in: time.Second, want: "0m"},
+		{in: -time.Hour, want: "0m"},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.in); got != tt.want {
+			t.Errorf("Format(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}